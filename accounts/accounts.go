@@ -0,0 +1,214 @@
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"gin-app/authz"
+	"gin-app/db"
+	sqlc "gin-app/db/sqlc"
+	"gin-app/metrics"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUserNotFound        = errors.New("user not found")
+	ErrNotPending          = errors.New("user is not a pending recruiter")
+	ErrCompanyExists       = errors.New("recruiter already has a company")
+	ErrCompanyNameRequired = errors.New("company name is required")
+	ErrNotSuspendable      = errors.New("only active applicants and recruiters can be suspended")
+	ErrNotSuspended        = errors.New("user is not suspended")
+	ErrReasonRequired      = errors.New("a reason is required")
+)
+
+// Service owns the account writes that touch several tables: recruiter
+// onboarding and suspensions. Each method runs in one transaction and locks
+// the user row first, so concurrent requests for the same user serialize.
+type Service struct {
+	DB db.TxBeginner
+}
+
+func NewService(conn db.TxBeginner) *Service {
+	return &Service{DB: conn}
+}
+
+// RemovedRecruiter is what a rejected or cancelled signup looked like before
+// it was soft deleted. Company is nil if none had been created yet.
+type RemovedRecruiter struct {
+	User    sqlc.User
+	Company *sqlc.Company
+}
+
+// ApproveRecruiter promotes a pending recruiter and returns the user before
+// and after the change.
+func (s *Service) ApproveRecruiter(ctx context.Context, id uuid.UUID) (sqlc.User, sqlc.User, error) {
+	var before, after sqlc.User
+	err := db.InTx(ctx, s.DB, func(q *sqlc.Queries) error {
+		var err error
+		before, err = lockPendingRecruiter(ctx, q, id)
+		if err != nil {
+			return err
+		}
+		if err := q.ApproveRecruiter(ctx, id); err != nil {
+			return err
+		}
+		after, err = q.GetUserByID(ctx, id)
+		return err
+	})
+	if err == nil {
+		metrics.RecruitersApproved.Inc()
+	}
+	return before, after, err
+}
+
+// RejectRecruiter soft deletes a pending recruiter and their company and
+// signs them out everywhere.
+func (s *Service) RejectRecruiter(ctx context.Context, id uuid.UUID) (RemovedRecruiter, error) {
+	return s.removePendingRecruiter(ctx, id)
+}
+
+// CancelSignup is RejectRecruiter initiated by the recruiter themselves.
+func (s *Service) CancelSignup(ctx context.Context, id uuid.UUID) (RemovedRecruiter, error) {
+	return s.removePendingRecruiter(ctx, id)
+}
+
+func (s *Service) removePendingRecruiter(ctx context.Context, id uuid.UUID) (RemovedRecruiter, error) {
+	var removed RemovedRecruiter
+	err := db.InTx(ctx, s.DB, func(q *sqlc.Queries) error {
+		user, err := lockPendingRecruiter(ctx, q, id)
+		if err != nil {
+			return err
+		}
+		removed.User = user
+
+		recruiterID := uuid.NullUUID{UUID: id, Valid: true}
+		company, err := q.GetCompanyByRecruiterID(ctx, recruiterID)
+		if err == nil {
+			removed.Company = &company
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		// Children first, mirroring the foreign keys, so the company never
+		// outlives its recruiter even for the length of the transaction.
+		if err := q.RejectCompany(ctx, recruiterID); err != nil {
+			return err
+		}
+		if err := q.RejectRecruiter(ctx, id); err != nil {
+			return err
+		}
+		return q.DeleteUserSessions(ctx, recruiterID)
+	})
+	return removed, err
+}
+
+// CreateCompany creates the company a pending recruiter signs up with. A
+// recruiter has at most one company.
+func (s *Service) CreateCompany(ctx context.Context, recruiterID uuid.UUID, name, description, logo string) (sqlc.Company, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return sqlc.Company{}, ErrCompanyNameRequired
+	}
+
+	var company sqlc.Company
+	err := db.InTx(ctx, s.DB, func(q *sqlc.Queries) error {
+		if _, err := lockPendingRecruiter(ctx, q, recruiterID); err != nil {
+			return err
+		}
+		_, err := q.GetCompanyByRecruiterID(ctx, uuid.NullUUID{UUID: recruiterID, Valid: true})
+		if err == nil {
+			return ErrCompanyExists
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		company, err = q.CreateCompany(ctx, sqlc.CreateCompanyParams{
+			ID:          uuid.New(),
+			RecruiterID: uuid.NullUUID{UUID: recruiterID, Valid: true},
+			Name:        name,
+			Description: sql.NullString{String: description, Valid: true},
+			Logo:        sql.NullString{String: logo, Valid: logo != ""},
+		})
+		return err
+	})
+	return company, err
+}
+
+// Suspend blocks an active applicant or recruiter from signing in until
+// until, or indefinitely if until is zero, and signs them out everywhere. A
+// suspended recruiter's job postings are hidden meanwhile.
+func (s *Service) Suspend(ctx context.Context, id uuid.UUID, reason string, until time.Time) (sqlc.User, sqlc.User, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return sqlc.User{}, sqlc.User{}, ErrReasonRequired
+	}
+
+	var before, after sqlc.User
+	err := db.InTx(ctx, s.DB, func(q *sqlc.Queries) error {
+		var err error
+		before, err = lockUser(ctx, q, id)
+		if err != nil {
+			return err
+		}
+		if before.Role == "admin" || before.Status != authz.StatusActive {
+			return ErrNotSuspendable
+		}
+		err = q.SuspendUser(ctx, sqlc.SuspendUserParams{
+			ID:               id,
+			SuspendedUntil:   sql.NullTime{Time: until, Valid: !until.IsZero()},
+			SuspensionReason: sql.NullString{String: reason, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.DeleteUserSessions(ctx, uuid.NullUUID{UUID: id, Valid: true}); err != nil {
+			return err
+		}
+		after, err = q.GetUserByID(ctx, id)
+		return err
+	})
+	return before, after, err
+}
+
+// Unsuspend lifts a suspension before it expires.
+func (s *Service) Unsuspend(ctx context.Context, id uuid.UUID) (sqlc.User, sqlc.User, error) {
+	var before, after sqlc.User
+	err := db.InTx(ctx, s.DB, func(q *sqlc.Queries) error {
+		var err error
+		before, err = lockUser(ctx, q, id)
+		if err != nil {
+			return err
+		}
+		if before.Status != authz.StatusSuspended {
+			return ErrNotSuspended
+		}
+		if err := q.UnsuspendUser(ctx, id); err != nil {
+			return err
+		}
+		after, err = q.GetUserByID(ctx, id)
+		return err
+	})
+	return before, after, err
+}
+
+func lockUser(ctx context.Context, q *sqlc.Queries, id uuid.UUID) (sqlc.User, error) {
+	user, err := q.LockUser(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && user.DeletedAt.Valid) {
+		return sqlc.User{}, ErrUserNotFound
+	}
+	return user, err
+}
+
+func lockPendingRecruiter(ctx context.Context, q *sqlc.Queries, id uuid.UUID) (sqlc.User, error) {
+	user, err := lockUser(ctx, q, id)
+	if err != nil {
+		return sqlc.User{}, err
+	}
+	if user.Status != authz.StatusPending {
+		return sqlc.User{}, ErrNotPending
+	}
+	return user, nil
+}
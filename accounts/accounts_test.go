@@ -0,0 +1,140 @@
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"gin-app/authz"
+	"gin-app/db/dbtest"
+	sqlc "gin-app/db/sqlc"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// createRecruiter adds a recruiter with the given status and a session. The
+// user is deleted when the test ends, taking any company and sessions along.
+func createRecruiter(t *testing.T, conn *sql.DB, status string) (sqlc.User, sqlc.Session) {
+	t.Helper()
+	ctx := context.Background()
+	q := sqlc.New(conn)
+	user, err := q.CreateUser(ctx, sqlc.CreateUserParams{
+		Name:   "Test Recruiter",
+		Email:  uuid.NewString() + "@example.com",
+		Role:   "recruiter",
+		Status: status,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Exec("DELETE FROM users WHERE id = $1", user.ID) })
+	session, err := q.CreateOrUpdateSession(ctx, sqlc.CreateOrUpdateSessionParams{
+		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+		Token:  uuid.NewString(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user, session
+}
+
+func TestRejectRecruiter(t *testing.T) {
+	conn := dbtest.Open(t)
+	s, q := NewService(conn), sqlc.New(conn)
+	ctx := context.Background()
+	user, session := createRecruiter(t, conn, authz.StatusPending)
+	company, err := s.CreateCompany(ctx, user.ID, "Acme", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := s.RejectRecruiter(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed.User.ID != user.ID || removed.Company == nil || removed.Company.ID != company.ID {
+		t.Errorf("removed = %+v, want the user and company %s", removed, company.ID)
+	}
+	if _, err := q.GetUserByEmail(ctx, user.Email); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("user still live: %v", err)
+	}
+	if _, err := q.GetCompanyByID(ctx, company.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("company still live: %v", err)
+	}
+	if _, err := q.GetSession(ctx, session.Token); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("session still exists: %v", err)
+	}
+
+	if _, err := s.RejectRecruiter(ctx, user.ID); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("rejecting twice returned %v, want %v", err, ErrUserNotFound)
+	}
+}
+
+func TestRejectRecruiterWithoutCompany(t *testing.T) {
+	conn := dbtest.Open(t)
+	s := NewService(conn)
+	user, _ := createRecruiter(t, conn, authz.StatusPending)
+
+	removed, err := s.CancelSignup(context.Background(), user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed.Company != nil {
+		t.Errorf("removed company %+v, want none", removed.Company)
+	}
+}
+
+func TestRejectRecruiterRequiresPending(t *testing.T) {
+	conn := dbtest.Open(t)
+	s, q := NewService(conn), sqlc.New(conn)
+	ctx := context.Background()
+	user, session := createRecruiter(t, conn, authz.StatusActive)
+
+	if _, err := s.RejectRecruiter(ctx, user.ID); !errors.Is(err, ErrNotPending) {
+		t.Fatalf("RejectRecruiter returned %v, want %v", err, ErrNotPending)
+	}
+	if _, err := q.GetUserByEmail(ctx, user.Email); err != nil {
+		t.Errorf("active recruiter was removed: %v", err)
+	}
+	if _, err := q.GetSession(ctx, session.Token); err != nil {
+		t.Errorf("active recruiter was signed out: %v", err)
+	}
+}
+
+func TestCreateCompany(t *testing.T) {
+	conn := dbtest.Open(t)
+	s, q := NewService(conn), sqlc.New(conn)
+	ctx := context.Background()
+	pending, _ := createRecruiter(t, conn, authz.StatusPending)
+	active, _ := createRecruiter(t, conn, authz.StatusActive)
+
+	company, err := s.CreateCompany(ctx, pending.ID, "  Acme  ", "Anvils", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if company.Name != "Acme" || company.Logo.Valid {
+		t.Errorf("company = %q with logo %v, want %q without", company.Name, company.Logo.Valid, "Acme")
+	}
+
+	tests := []struct {
+		name        string
+		recruiterID uuid.UUID
+		companyName string
+		want        error
+	}{
+		{"blank name", pending.ID, "   ", ErrCompanyNameRequired},
+		{"second company", pending.ID, "Acme Two", ErrCompanyExists},
+		{"approved recruiter", active.ID, "Acme", ErrNotPending},
+		{"unknown user", uuid.New(), "Acme", ErrUserNotFound},
+	}
+	for _, tt := range tests {
+		if _, err := s.CreateCompany(ctx, tt.recruiterID, tt.companyName, "", ""); !errors.Is(err, tt.want) {
+			t.Errorf("%s: CreateCompany returned %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	got, err := q.GetCompanyByRecruiterID(ctx, uuid.NullUUID{UUID: pending.ID, Valid: true})
+	if err != nil || got.ID != company.ID {
+		t.Errorf("recruiter's company = %s (%v), want %s", got.ID, err, company.ID)
+	}
+}
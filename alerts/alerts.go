@@ -0,0 +1,193 @@
+package alerts
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"gin-app/analytics"
+	db "gin-app/db/sqlc"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Frequencies are how often a saved search can send alerts. Instant alerts
+// go out on the worker's next run after a match is published; daily ones
+// at most once a day.
+var Frequencies = []string{"instant", "daily"}
+
+// ProfileMatch is the share of a posting's skills an applicant must have
+// for a search that matches on their profile skills.
+const ProfileMatch = 0.5
+
+var (
+	ErrEmptySearch      = errors.New("a saved search needs keywords, skills, a minimum salary or profile matching")
+	ErrInvalidSalary    = errors.New("minimum salary must be a positive whole number")
+	ErrInvalidFrequency = errors.New("frequency must be instant or daily")
+)
+
+// Criteria is what a saved search looks for. Every criterion given must
+// hold for a posting to match.
+type Criteria struct {
+	Keywords           string
+	Skills             []string
+	MinSalary          sql.NullInt32
+	MatchProfileSkills bool
+}
+
+// ParseSearch reads a saved search from the applicant's form: keywords,
+// comma-separated skills, min_salary, match_profile and frequency.
+func ParseSearch(applicantID uuid.UUID, form url.Values) (db.CreateSavedSearchParams, error) {
+	params := db.CreateSavedSearchParams{
+		ApplicantID:        applicantID,
+		Keywords:           strings.Join(strings.Fields(form.Get("keywords")), " "),
+		Skills:             []string{},
+		MatchProfileSkills: form.Get("match_profile") == "on",
+		Frequency:          form.Get("frequency"),
+	}
+	for _, skill := range strings.Split(form.Get("skills"), ",") {
+		if skill = strings.TrimSpace(skill); skill != "" {
+			params.Skills = append(params.Skills, skill)
+		}
+	}
+	if minSalary := strings.TrimSpace(form.Get("min_salary")); minSalary != "" {
+		n, err := strconv.ParseInt(minSalary, 10, 32)
+		if err != nil || n <= 0 {
+			return params, ErrInvalidSalary
+		}
+		params.MinSalary = sql.NullInt32{Int32: int32(n), Valid: true}
+	}
+	if !slices.Contains(Frequencies, params.Frequency) {
+		return params, ErrInvalidFrequency
+	}
+	if params.Keywords == "" && len(params.Skills) == 0 && !params.MinSalary.Valid && !params.MatchProfileSkills {
+		return params, ErrEmptySearch
+	}
+	return params, nil
+}
+
+// Match reports whether post meets the criteria. Keywords are matched
+// case-insensitively against the position, company, description and skills;
+// profileSkills are the applicant's own skills.
+func (c Criteria) Match(post db.JobPosting, profileSkills []string) bool {
+	text := strings.ToLower(strings.Join(append([]string{post.Position, post.CompanyName, post.Description.String}, post.Skills...), " "))
+	for _, word := range strings.Fields(strings.ToLower(c.Keywords)) {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	if analytics.SkillMatch(c.Skills, post.Skills) < 1 {
+		return false
+	}
+	if c.MinSalary.Valid {
+		top, ok := MaxSalary(post.Salary.String)
+		if !ok || top < int64(c.MinSalary.Int32) {
+			return false
+		}
+	}
+	if c.MatchProfileSkills && analytics.SkillMatch(post.Skills, profileSkills) < ProfileMatch {
+		return false
+	}
+	return true
+}
+
+// String describes the criteria in an alert, such as
+// `"remote backend"; skills Go, SQL; salary 90000+`.
+func (c Criteria) String() string {
+	var parts []string
+	if c.Keywords != "" {
+		parts = append(parts, fmt.Sprintf("%q", c.Keywords))
+	}
+	if len(c.Skills) > 0 {
+		parts = append(parts, "skills "+strings.Join(c.Skills, ", "))
+	}
+	if c.MinSalary.Valid {
+		parts = append(parts, fmt.Sprintf("salary %d+", c.MinSalary.Int32))
+	}
+	if c.MatchProfileSkills {
+		parts = append(parts, "matching your skills")
+	}
+	return strings.Join(parts, "; ")
+}
+
+// salaryAmount matches a number with an optional k or m multiplier and an
+// optional currency before (a symbol or code) or after it (a code).
+var salaryAmount = regexp.MustCompile(`(?i)(?:([$€£¥]|\b(?:usd|eur|gbp|cad|aud|chf)\b)\s*)?(\d+(?:[.,]\d+)*)(?:\s*([km])\b)?(?:\s*\b(usd|eur|gbp|cad|aud|chf)\b)?`)
+
+// rangeSeparator is the text between the two ends of a salary range.
+var rangeSeparator = regexp.MustCompile(`(?i)^\s*(?:-|–|—|to)\s*$`)
+
+// MaxSalary reads the highest amount in a free-text salary such as
+// "$80,000 - $100,000", "80k-100k" or "€50.000". Only amounts with a
+// currency or at either end of a range count, so "$90,000 + 401k match"
+// is 90000. It reports false if the text has no such amount, so postings
+// with an unstated salary never meet a minimum.
+func MaxSalary(salary string) (int64, bool) {
+	type amount struct {
+		start, end int
+		digits     string
+		multiplier string
+		currency   bool
+		inRange    bool
+	}
+	var amounts []amount
+	for _, m := range salaryAmount.FindAllStringSubmatchIndex(salary, -1) {
+		a := amount{start: m[0], end: m[1], digits: salary[m[4]:m[5]], currency: m[2] >= 0 || m[8] >= 0}
+		if m[6] >= 0 {
+			a.multiplier = strings.ToLower(salary[m[6]:m[7]])
+		}
+		if n := len(amounts); n > 0 && rangeSeparator.MatchString(salary[amounts[n-1].end:a.start]) {
+			prev := &amounts[n-1]
+			prev.inRange, a.inRange = true, true
+			// "$80-100k" means 80k to 100k.
+			if prev.multiplier == "" {
+				prev.multiplier = a.multiplier
+			}
+		}
+		amounts = append(amounts, a)
+	}
+
+	var top float64
+	found := false
+	for _, a := range amounts {
+		if !a.currency && !a.inRange {
+			continue
+		}
+		n, ok := parseAmount(a.digits)
+		if !ok {
+			continue
+		}
+		switch a.multiplier {
+		case "k":
+			n *= 1e3
+		case "m":
+			n *= 1e6
+		}
+		top, found = max(top, n), true
+	}
+	return int64(top), found
+}
+
+// parseAmount reads a number grouped in either the 1,234.5 or the 1.234,5
+// style: a separator followed by exactly three digits groups thousands, and
+// a last one followed by one or two digits is the decimal point.
+func parseAmount(s string) (float64, bool) {
+	groups := strings.FieldsFunc(s, func(r rune) bool { return r == '.' || r == ',' })
+	digits := groups[0]
+	for i, g := range groups[1:] {
+		switch {
+		case len(g) == 3:
+			digits += g
+		case len(g) < 3 && i == len(groups)-2:
+			digits += "." + g
+		default:
+			return 0, false
+		}
+	}
+	n, err := strconv.ParseFloat(digits, 64)
+	return n, err == nil
+}
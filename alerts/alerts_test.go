@@ -0,0 +1,88 @@
+package alerts
+
+import (
+	"database/sql"
+	db "gin-app/db/sqlc"
+	"testing"
+)
+
+func TestMaxSalary(t *testing.T) {
+	tests := []struct {
+		salary string
+		want   int64
+		ok     bool
+	}{
+		{"$80,000 - $100,000", 100000, true},
+		{"$80,000-100,000 per year", 100000, true},
+		{"80k-100k", 100000, true},
+		{"$80-100k", 100000, true},
+		{"80 to 95k", 95000, true},
+		{"$85.5k", 85500, true},
+		{"$1.2M", 1200000, true},
+		{"$90,000 + 401k match", 90000, true},
+		{"$90,000 + 401(k) match", 90000, true},
+		{"€50.000", 50000, true},
+		{"€45.000 – €55.000", 55000, true},
+		{"€50.000,50", 50000, true},
+		{"£60,000.00", 60000, true},
+		{"70000 EUR", 70000, true},
+		{"USD 120,000", 120000, true},
+		{"EUR 1.234.567", 1234567, true},
+		{"Competitive, 401k and 25 days off", 0, false},
+		{"Up to 20% bonus", 0, false},
+		{"Competitive", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := MaxSalary(tt.salary)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("MaxSalary(%q) = %d, %v; want %d, %v", tt.salary, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCriteriaMatch(t *testing.T) {
+	post := db.JobPosting{
+		Position:    "Backend Engineer",
+		CompanyName: "Acme",
+		Description: sql.NullString{String: "Remote-friendly team building payment APIs.", Valid: true},
+		Skills:      []string{"Go", "PostgreSQL"},
+		Salary:      sql.NullString{String: "$90,000 - $110,000 + 401k", Valid: true},
+	}
+	salary := func(n int32) sql.NullInt32 { return sql.NullInt32{Int32: n, Valid: true} }
+
+	tests := []struct {
+		name          string
+		criteria      Criteria
+		post          func(*db.JobPosting)
+		profileSkills []string
+		want          bool
+	}{
+		{"no criteria", Criteria{}, nil, nil, true},
+		{"keywords in any field", Criteria{Keywords: "backend ACME remote postgresql"}, nil, nil, true},
+		{"missing keyword", Criteria{Keywords: "backend frontend"}, nil, nil, false},
+		{"skills all present", Criteria{Skills: []string{"go"}}, nil, nil, true},
+		{"skill missing", Criteria{Skills: []string{"Go", "Rust"}}, nil, nil, false},
+		{"salary at the top of the range", Criteria{MinSalary: salary(110000)}, nil, nil, true},
+		{"salary above the range", Criteria{MinSalary: salary(120000)}, nil, nil, false},
+		{"401k is not a salary", Criteria{MinSalary: salary(200000)}, nil, nil, false},
+		{"salary in dotted thousands", Criteria{MinSalary: salary(50000)}, func(p *db.JobPosting) {
+			p.Salary = sql.NullString{String: "€50.000", Valid: true}
+		}, nil, true},
+		{"unstated salary", Criteria{MinSalary: salary(1)}, func(p *db.JobPosting) {
+			p.Salary = sql.NullString{}
+		}, nil, false},
+		{"profile has half the skills", Criteria{MatchProfileSkills: true}, nil, []string{"Go"}, true},
+		{"profile has too few skills", Criteria{MatchProfileSkills: true}, nil, []string{"Java"}, false},
+		{"every criterion must hold", Criteria{Keywords: "backend", Skills: []string{"Go"}, MinSalary: salary(120000)}, nil, nil, false},
+	}
+	for _, tt := range tests {
+		p := post
+		if tt.post != nil {
+			tt.post(&p)
+		}
+		if got := tt.criteria.Match(p, tt.profileSkills); got != tt.want {
+			t.Errorf("%s: Match = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
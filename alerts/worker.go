@@ -0,0 +1,138 @@
+package alerts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "gin-app/db/sqlc"
+	"gin-app/metrics"
+	"gin-app/notify"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// Worker matches newly published postings against saved searches and sends
+// each applicant a digest of the matches. Searches are claimed by moving
+// their cursor forward, so several workers can run at once and no posting
+// is alerted twice. A digest that fails to send is logged and not retried,
+// so a broken mail server cannot pile up stale alerts.
+type Worker struct {
+	Queries   *db.Queries
+	Notifier  notify.Notifier
+	BaseURL   string
+	Interval  time.Duration
+	BatchSize int32
+}
+
+func NewWorker(queries *db.Queries, notifier notify.Notifier, baseURL string, interval time.Duration) *Worker {
+	return &Worker{
+		Queries:   queries,
+		Notifier:  notifier,
+		BaseURL:   strings.TrimSuffix(baseURL, "/"),
+		Interval:  interval,
+		BatchSize: 50,
+	}
+}
+
+// Run sends alerts until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		if err := w.ProcessDue(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("processing job alerts", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue claims one batch of saved searches with new postings to check
+// and alerts their owners of any matches.
+func (w *Worker) ProcessDue(ctx context.Context) error {
+	searches, err := w.Queries.ClaimDueSavedSearches(ctx, w.BatchSize)
+	if err != nil {
+		return err
+	}
+	for _, s := range searches {
+		if err := w.alert(ctx, s); err != nil {
+			slog.Error("sending job alert", "saved_search_id", s.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+func (w *Worker) alert(ctx context.Context, s db.ClaimDueSavedSearchesRow) error {
+	posts, err := w.Queries.ListJobPostsPublishedBetween(ctx, db.ListJobPostsPublishedBetweenParams{
+		Since:       s.Since,
+		Until:       s.Until,
+		ApplicantID: s.ApplicantID,
+	})
+	if err != nil {
+		return err
+	}
+	var profileSkills []string
+	if s.MatchProfileSkills {
+		set, err := w.Queries.GetApplicantSkillSet(ctx, s.ApplicantID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		profileSkills = set.Skills
+	}
+	criteria := Criteria{
+		Keywords:           s.Keywords,
+		Skills:             s.Skills,
+		MinSalary:          s.MinSalary,
+		MatchProfileSkills: s.MatchProfileSkills,
+	}
+	var matches []db.JobPosting
+	for _, post := range posts {
+		if criteria.Match(post, profileSkills) {
+			matches = append(matches, post)
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	user, err := w.Queries.GetUserByID(ctx, s.ApplicantID)
+	if err != nil {
+		return err
+	}
+	if err := w.Notifier.Notify(ctx, w.digest(user, criteria, matches)); err != nil {
+		return err
+	}
+	metrics.JobAlertsSent.WithLabelValues(s.Frequency).Inc()
+	return nil
+}
+
+// digest lists the matching postings, linking each to its job page.
+func (w *Worker) digest(user db.User, c Criteria, matches []db.JobPosting) notify.Message {
+	subject := "1 new job matches your saved search"
+	if len(matches) > 1 {
+		subject = fmt.Sprintf("%d new jobs match your saved search", len(matches))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Hi %s,\n\nThese jobs were posted since your last alert for %s:\n", user.Name, c)
+	for _, post := range matches {
+		fmt.Fprintf(&b, "\n%s at %s", post.Position, post.CompanyName)
+		if post.Salary.String != "" {
+			fmt.Fprintf(&b, " (%s)", post.Salary.String)
+		}
+		fmt.Fprintf(&b, "\n%s/jobs/%s?src=alert\n", w.BaseURL, post.ID)
+	}
+	b.WriteString("\nYou can change or remove your saved searches on the Job Alerts page.")
+	return notify.Message{
+		UserID:  user.ID,
+		Email:   user.Email,
+		Kind:    "job_alert",
+		Subject: subject,
+		Body:    b.String(),
+		Link:    "/applicant/job-alerts",
+	}
+}
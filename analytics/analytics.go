@@ -0,0 +1,230 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	db "gin-app/db/sqlc"
+	"time"
+)
+
+const (
+	// SignupDays is how far back the signups chart goes.
+	SignupDays = 90
+	// topN bounds the per-company and per-posting charts.
+	topN = 10
+)
+
+// FunnelStages are the application stages in hiring order. Rejected can
+// follow any of them, so it is not part of the funnel.
+var FunnelStages = []string{"applied", "screening", "interview", "offer", "hired"}
+
+// Service computes the admin analytics from aggregate queries. Signups come
+// from a materialized view that Refresher keeps current; everything else is
+// read live.
+type Service struct {
+	Queries *db.Queries
+}
+
+func NewService(queries *db.Queries) *Service {
+	return &Service{Queries: queries}
+}
+
+// Overview is everything the admin analytics dashboard shows.
+type Overview struct {
+	Signups      Chart
+	Companies    Chart
+	Postings     Chart
+	FunnelChart  Chart
+	Funnel       []FunnelStep
+	Hires        int64
+	TimeToHire   Span
+	TimeInStage  []StageTime
+	PendingQueue PendingQueue
+}
+
+// FunnelStep is how many applications ever reached a stage, as a percentage
+// of those reaching the stage before it and of all applications.
+type FunnelStep struct {
+	Stage        string
+	Applications int64
+	FromPrevious float64
+	FromApplied  float64
+}
+
+// StageTime is the median time applications spent in a stage before moving
+// on. Applications still in the stage are not counted.
+type StageTime struct {
+	Stage       string
+	Transitions int64
+	Median      Span
+}
+
+// PendingQueue describes recruiters waiting for approval.
+type PendingQueue struct {
+	Pending int64
+	Oldest  Span
+	Median  Span
+}
+
+// Chart is a Chart.js configuration's type and data.
+type Chart struct {
+	Type     string    `json:"type"`
+	Labels   []string  `json:"labels"`
+	Datasets []Dataset `json:"datasets"`
+}
+
+type Dataset struct {
+	Label string  `json:"label"`
+	Data  []int64 `json:"data"`
+}
+
+// JSON encodes the chart for the template's data-chart attribute.
+func (c Chart) JSON() (string, error) {
+	b, err := json.Marshal(c)
+	return string(b), err
+}
+
+// Span is a duration shown in days, hours or minutes, whichever reads best.
+type Span time.Duration
+
+func seconds(s float64) Span {
+	return Span(time.Duration(s * float64(time.Second)))
+}
+
+func (s Span) String() string {
+	d := time.Duration(s)
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%.1f days", d.Hours()/24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%.1f hours", d.Hours())
+	default:
+		return fmt.Sprintf("%d minutes", int(d.Minutes()))
+	}
+}
+
+// Overview runs the analytics queries.
+func (s *Service) Overview(ctx context.Context) (Overview, error) {
+	var o Overview
+	var err error
+	if o.Signups, err = s.signups(ctx); err != nil {
+		return o, err
+	}
+
+	companies, err := s.Queries.ListActivePostingsPerCompany(ctx, topN)
+	if err != nil {
+		return o, err
+	}
+	o.Companies = Chart{Type: "horizontalBar", Datasets: []Dataset{{Label: "Active postings"}}}
+	for _, c := range companies {
+		o.Companies.Labels = append(o.Companies.Labels, c.Name)
+		o.Companies.Datasets[0].Data = append(o.Companies.Datasets[0].Data, c.Postings)
+	}
+
+	postings, err := s.Queries.ListApplicationsPerPosting(ctx, topN)
+	if err != nil {
+		return o, err
+	}
+	o.Postings = Chart{Type: "horizontalBar", Datasets: []Dataset{{Label: "Applications"}}}
+	for _, p := range postings {
+		o.Postings.Labels = append(o.Postings.Labels, p.Position+" ("+p.CompanyName+")")
+		o.Postings.Datasets[0].Data = append(o.Postings.Datasets[0].Data, p.Applications)
+	}
+
+	if err := s.funnel(ctx, &o); err != nil {
+		return o, err
+	}
+
+	hires, err := s.Queries.GetTimeToHire(ctx)
+	if err != nil {
+		return o, err
+	}
+	o.Hires, o.TimeToHire = hires.Hires, seconds(hires.MedianSeconds)
+
+	stays, err := s.Queries.ListTimeInStage(ctx)
+	if err != nil {
+		return o, err
+	}
+	byStage := make(map[string]StageTime, len(stays))
+	for _, st := range stays {
+		byStage[st.Stage] = StageTime{Stage: st.Stage, Transitions: st.Transitions, Median: seconds(st.MedianSeconds)}
+	}
+	for _, stage := range FunnelStages {
+		if st, ok := byStage[stage]; ok {
+			o.TimeInStage = append(o.TimeInStage, st)
+		}
+	}
+
+	queue, err := s.Queries.GetPendingRecruiterQueue(ctx)
+	if err != nil {
+		return o, err
+	}
+	o.PendingQueue = PendingQueue{
+		Pending: queue.Pending,
+		Oldest:  seconds(queue.OldestSeconds),
+		Median:  seconds(queue.MedianSeconds),
+	}
+	return o, nil
+}
+
+// signups charts daily signups per role over the last SignupDays, with a
+// zero for days nobody signed up.
+func (s *Service) signups(ctx context.Context) (Chart, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -SignupDays+1)
+	rows, err := s.Queries.ListDailySignups(ctx, since)
+	if err != nil {
+		return Chart{}, err
+	}
+	chart := Chart{Type: "line"}
+	for day := since; !day.After(today); day = day.AddDate(0, 0, 1) {
+		chart.Labels = append(chart.Labels, day.Format("Jan 2"))
+	}
+	datasets := map[string]int{}
+	for _, row := range rows {
+		i, ok := datasets[row.Role]
+		if !ok {
+			i = len(chart.Datasets)
+			datasets[row.Role] = i
+			chart.Datasets = append(chart.Datasets, Dataset{Label: row.Role, Data: make([]int64, len(chart.Labels))})
+		}
+		if day := int(row.Day.Sub(since).Hours() / 24); day >= 0 && day < len(chart.Labels) {
+			chart.Datasets[i].Data[day] = row.Signups
+		}
+	}
+	return chart, nil
+}
+
+func (s *Service) funnel(ctx context.Context, o *Overview) error {
+	rows, err := s.Queries.GetHiringFunnel(ctx)
+	if err != nil {
+		return err
+	}
+	reached := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		reached[row.Stage] = row.Applications
+	}
+	o.FunnelChart = Chart{Type: "bar", Labels: FunnelStages, Datasets: []Dataset{{Label: "Applications"}}}
+	applied := reached[FunnelStages[0]]
+	var previous int64
+	for i, stage := range FunnelStages {
+		step := FunnelStep{Stage: stage, Applications: reached[stage]}
+		if i == 0 {
+			previous = step.Applications
+		}
+		step.FromPrevious = percent(step.Applications, previous)
+		step.FromApplied = percent(step.Applications, applied)
+		previous = step.Applications
+		o.Funnel = append(o.Funnel, step)
+		o.FunnelChart.Datasets[0].Data = append(o.FunnelChart.Datasets[0].Data, step.Applications)
+	}
+	return nil
+}
+
+func percent(n, of int64) float64 {
+	if of == 0 {
+		return 0
+	}
+	return 100 * float64(n) / float64(of)
+}
@@ -0,0 +1,158 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Stages are every application stage, in the order recruiters see them.
+var Stages = append(append([]string{}, FunnelStages...), "rejected")
+
+// ViewSources are where a job page view can come from, given as the page's
+// src parameter. Anything else counts as "direct".
+var ViewSources = []string{"dashboard", "recently_viewed", "saved", "alert", "direct"}
+
+// ViewSource returns src if it is one of ViewSources, or "direct".
+func ViewSource(src string) string {
+	if slices.Contains(ViewSources, src) {
+		return src
+	}
+	return "direct"
+}
+
+// SkillMatchBuckets label the skill-match distribution, by quarter of the
+// posting's skills an applicant has.
+var SkillMatchBuckets = []string{"0-24%", "25-49%", "50-74%", "75-100%"}
+
+// PostingStats is a recruiter's view of how one of their postings performs.
+type PostingStats struct {
+	ID           uuid.UUID
+	Position     string
+	CreatedAt    sql.NullTime
+	Views        int64
+	Viewers      int64
+	Applications int64
+	// ApplyRate is the percentage of distinct viewers who applied.
+	ApplyRate  float64
+	Stages     []Count
+	Sources    []Count
+	SkillMatch []Count
+}
+
+// Count is a labelled number in a breakdown.
+type Count struct {
+	Label string
+	Count int64
+}
+
+// Postings returns stats for each live posting of a company.
+func (s *Service) Postings(ctx context.Context, companyID uuid.UUID) ([]PostingStats, error) {
+	id := uuid.NullUUID{UUID: companyID, Valid: true}
+	rows, err := s.Queries.ListCompanyPostingStats(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	breakdown, err := s.Queries.ListCompanyApplicationBreakdown(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := s.Queries.ListCompanyApplicantSkills(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := map[uuid.UUID]map[string]int64{}
+	sources := map[uuid.UUID]map[string]int64{}
+	for _, b := range breakdown {
+		add(stages, b.JobPostingID, b.Stage, b.Applications)
+		add(sources, b.JobPostingID, b.Source, b.Applications)
+	}
+	required := make(map[uuid.UUID][]string, len(rows))
+	for _, row := range rows {
+		required[row.ID] = row.Skills
+	}
+	matches := map[uuid.UUID][]int64{}
+	for _, applicant := range skills {
+		if matches[applicant.JobPostingID] == nil {
+			matches[applicant.JobPostingID] = make([]int64, len(SkillMatchBuckets))
+		}
+		bucket := int(SkillMatch(required[applicant.JobPostingID], applicant.Skills) * float64(len(SkillMatchBuckets)))
+		matches[applicant.JobPostingID][min(bucket, len(SkillMatchBuckets)-1)]++
+	}
+
+	stats := make([]PostingStats, 0, len(rows))
+	for _, row := range rows {
+		p := PostingStats{
+			ID:           row.ID,
+			Position:     row.Position,
+			CreatedAt:    row.CreatedAt,
+			Views:        row.Views,
+			Viewers:      row.Viewers,
+			Applications: row.Applications,
+			ApplyRate:    percent(row.Applications, row.Viewers),
+		}
+		for _, stage := range Stages {
+			p.Stages = append(p.Stages, Count{Label: stage, Count: stages[row.ID][stage]})
+		}
+		p.Sources = sortedCounts(sources[row.ID])
+		for i, label := range SkillMatchBuckets {
+			var n int64
+			if m := matches[row.ID]; m != nil {
+				n = m[i]
+			}
+			p.SkillMatch = append(p.SkillMatch, Count{Label: label, Count: n})
+		}
+		stats = append(stats, p)
+	}
+	return stats, nil
+}
+
+// SkillMatch returns the fraction of required skills found in has, compared
+// case-insensitively. A posting that lists no skills matches everyone.
+func SkillMatch(required, has []string) float64 {
+	if len(required) == 0 {
+		return 1
+	}
+	held := make(map[string]bool, len(has))
+	for _, skill := range has {
+		held[normalizeSkill(skill)] = true
+	}
+	var matched int
+	for _, skill := range required {
+		if held[normalizeSkill(skill)] {
+			matched++
+		}
+	}
+	return float64(matched) / float64(len(required))
+}
+
+func normalizeSkill(skill string) string {
+	return strings.ToLower(strings.TrimSpace(skill))
+}
+
+func add(counts map[uuid.UUID]map[string]int64, id uuid.UUID, label string, n int64) {
+	if counts[id] == nil {
+		counts[id] = map[string]int64{}
+	}
+	counts[id][label] += n
+}
+
+// sortedCounts orders a breakdown largest first.
+func sortedCounts(counts map[string]int64) []Count {
+	list := make([]Count, 0, len(counts))
+	for label, n := range counts {
+		list = append(list, Count{Label: label, Count: n})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Label < list[j].Label
+	})
+	return list
+}
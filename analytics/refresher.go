@@ -0,0 +1,36 @@
+package analytics
+
+import (
+	"context"
+	db "gin-app/db/sqlc"
+	"log/slog"
+	"time"
+)
+
+// Refresher rebuilds the analytics materialized views every Interval. The
+// refresh is concurrent, so the dashboard keeps reading the previous data
+// while it runs.
+type Refresher struct {
+	Queries  *db.Queries
+	Interval time.Duration
+}
+
+func NewRefresher(queries *db.Queries, interval time.Duration) *Refresher {
+	return &Refresher{Queries: queries, Interval: interval}
+}
+
+// Run refreshes once per Interval until ctx is cancelled.
+func (r *Refresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		if err := r.Queries.RefreshDailySignups(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("refreshing analytics views", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"gin-app/audit"
+	db "gin-app/db/sqlc"
+	"gin-app/logging"
+	"gin-app/metrics"
+	"gin-app/skills"
+	"gin-app/webhooks"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Service serves the JSON API used by scripts and sync jobs. Routes are
+// mounted behind middlewares.TokenAuthMiddleware, which puts the caller's
+// "id" and "role" on the context.
+type Service struct {
+	Queries  *db.Queries
+	Webhooks *webhooks.Service
+	Skills   *skills.Service
+	Audit    *audit.Recorder
+}
+
+func NewService(queries *db.Queries, webhooksService *webhooks.Service, skillsService *skills.Service, recorder *audit.Recorder) *Service {
+	return &Service{Queries: queries, Webhooks: webhooksService, Skills: skillsService, Audit: recorder}
+}
+
+type JobPost struct {
+	ID              uuid.UUID `json:"id"`
+	CompanyID       string    `json:"company_id,omitempty"`
+	CompanyName     string    `json:"company_name"`
+	Position        string    `json:"position"`
+	Skills          []string  `json:"skills"`
+	PreferredSkills []string  `json:"preferred_skills"`
+	Description     string    `json:"description,omitempty"`
+	Salary          string    `json:"salary,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type CreateJobPostRequest struct {
+	CompanyName     string   `json:"company_name" binding:"required"`
+	Position        string   `json:"position" binding:"required"`
+	Skills          []string `json:"skills"`
+	PreferredSkills []string `json:"preferred_skills"`
+	Description     string   `json:"description"`
+	Salary          string   `json:"salary"`
+}
+
+type UpdateSkillsRequest struct {
+	Skills []SkillEntry `json:"skills" binding:"required"`
+}
+
+// SkillEntry is one of an applicant's skills. It is either a bare name, as
+// older clients send, or an object with a proficiency and years.
+type SkillEntry struct {
+	Name        string `json:"name"`
+	Proficiency string `json:"proficiency,omitempty"`
+	Years       *int32 `json:"years,omitempty"`
+}
+
+func (e *SkillEntry) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &e.Name); err == nil {
+		return nil
+	}
+	type entry SkillEntry
+	return json.Unmarshal(data, (*entry)(e))
+}
+
+func newJobPost(p db.JobPosting) JobPost {
+	post := JobPost{
+		ID:              p.ID,
+		CompanyName:     p.CompanyName,
+		Position:        p.Position,
+		Skills:          p.Skills,
+		PreferredSkills: p.PreferredSkills,
+		Description:     p.Description.String,
+		Salary:          p.Salary.String,
+		CreatedAt:       p.CreatedAt.Time,
+	}
+	if p.CompanyID.Valid {
+		post.CompanyID = p.CompanyID.UUID.String()
+	}
+	return post
+}
+
+func callerID(c *gin.Context) (uuid.UUID, bool) {
+	id, _ := c.Get("id")
+	s, _ := id.(string)
+	uid, err := uuid.Parse(s)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
+
+func (s *Service) MeHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"id":    c.GetString("id"),
+		"name":  c.GetString("name"),
+		"email": c.GetString("email"),
+		"role":  c.GetString("role"),
+	})
+}
+
+func (s *Service) ListJobPostsHandler(c *gin.Context) {
+	posts, err := s.Queries.GetAllJobPosts(c.Request.Context())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	out := make([]JobPost, 0, len(posts))
+	for _, p := range posts {
+		out = append(out, newJobPost(p))
+	}
+	c.JSON(http.StatusOK, gin.H{"job_posts": out})
+}
+
+func (s *Service) CreateJobPostHandler(c *gin.Context) {
+	var req CreateJobPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	uid, ok := callerID(c)
+	if !ok {
+		return
+	}
+	company, err := s.Queries.GetCompanyByRecruiterID(c.Request.Context(), uuid.NullUUID{UUID: uid, Valid: true})
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	params, err := s.Skills.CreateJobPost(c.Request.Context(), db.CreateJobPostParams{
+		ID:              uuid.New(),
+		RecruiterID:     uuid.NullUUID{UUID: uid, Valid: true},
+		CompanyID:       uuid.NullUUID{UUID: company.ID, Valid: true},
+		CompanyName:     req.CompanyName,
+		Position:        req.Position,
+		Skills:          req.Skills,
+		PreferredSkills: req.PreferredSkills,
+		Description:     sql.NullString{String: req.Description, Valid: true},
+		Salary:          sql.NullString{String: req.Salary, Valid: true},
+	})
+	if err != nil {
+		logging.FromGin(c).Error("creating job post", "error", err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	metrics.JobPostsCreated.Inc()
+	s.Audit.Record(c, audit.ActionJobPostCreated, audit.TargetJobPost, params.ID.String(), nil, webhooks.JobPostedData(params))
+	if err := s.Webhooks.Publish(c.Request.Context(), company.ID, webhooks.EventJobPosted, webhooks.JobPostedData(params)); err != nil {
+		logging.FromGin(c).Error("publishing webhook", "event", webhooks.EventJobPosted, "error", err)
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": params.ID})
+}
+
+// OwnedJobPost loads the live posting named by the :id parameter if it
+// belongs to the recruiter's company. Otherwise it aborts the request and
+// reports false; a posting of another company is reported as not found.
+func OwnedJobPost(c *gin.Context, queries *db.Queries, recruiterID uuid.UUID) (db.JobPosting, bool) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return db.JobPosting{}, false
+	}
+	company, err := queries.GetCompanyByRecruiterID(c.Request.Context(), uuid.NullUUID{UUID: recruiterID, Valid: true})
+	if err != nil && err != sql.ErrNoRows {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return db.JobPosting{}, false
+	}
+	jobPost, err := queries.GetJobPostByID(c.Request.Context(), jobID)
+	if err == nil && (company.ID == uuid.Nil || jobPost.CompanyID.UUID != company.ID) {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Job post not found"})
+			return db.JobPosting{}, false
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return db.JobPosting{}, false
+	}
+	return jobPost, true
+}
+
+func (s *Service) DeleteJobPostHandler(c *gin.Context) {
+	uid, ok := callerID(c)
+	if !ok {
+		return
+	}
+	jobPost, ok := OwnedJobPost(c, s.Queries, uid)
+	if !ok {
+		return
+	}
+	if err := s.Queries.DeleteJobPost(c.Request.Context(), jobPost.ID); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	s.Audit.Record(c, audit.ActionJobPostDeleted, audit.TargetJobPost, jobPost.ID.String(), audit.JobPostSnapshot(jobPost), nil)
+	c.Status(http.StatusNoContent)
+}
+
+func (s *Service) UpdateSkillsHandler(c *gin.Context) {
+	var req UpdateSkillsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	uid, ok := callerID(c)
+	if !ok {
+		return
+	}
+	entries := make([]skills.ApplicantSkill, 0, len(req.Skills))
+	for _, e := range req.Skills {
+		entry := skills.ApplicantSkill{Name: e.Name, Proficiency: e.Proficiency}
+		if e.Years != nil {
+			entry.Years = sql.NullInt32{Int32: *e.Years, Valid: true}
+		}
+		entries = append(entries, entry)
+	}
+	names, err := s.Skills.SetApplicantSkills(c.Request.Context(), uid, entries)
+	if err != nil {
+		if errors.Is(err, skills.ErrInvalidProficiency) || errors.Is(err, skills.ErrInvalidYears) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	s.Audit.Record(c, audit.ActionSkillsUpdated, audit.TargetUser, uid.String(), nil, gin.H{"skills": names})
+	c.JSON(http.StatusOK, gin.H{"skills": names})
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSkillEntryUnmarshalJSON(t *testing.T) {
+	years := func(n int32) *int32 { return &n }
+	tests := []struct {
+		name    string
+		body    string
+		want    []SkillEntry
+		wantErr bool
+	}{
+		{"bare names", `["Go", "golang"]`, []SkillEntry{{Name: "Go"}, {Name: "golang"}}, false},
+		{"objects", `[{"name": "Go", "proficiency": "expert", "years": 4}, {"name": "Docker"}]`, []SkillEntry{
+			{Name: "Go", Proficiency: "expert", Years: years(4)},
+			{Name: "Docker"},
+		}, false},
+		{"mixed", `["Go", {"name": "Rust", "years": 0}]`, []SkillEntry{
+			{Name: "Go"},
+			{Name: "Rust", Years: years(0)},
+		}, false},
+		{"number", `[42]`, nil, true},
+		{"years not a number", `[{"name": "Go", "years": "four"}]`, nil, true},
+	}
+	for _, tt := range tests {
+		var got []SkillEntry
+		err := json.Unmarshal([]byte(tt.body), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
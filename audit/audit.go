@@ -0,0 +1,232 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	db "gin-app/db/sqlc"
+	"gin-app/logging"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Actions recorded in the audit log.
+const (
+	ActionRecruiterApproved  = "recruiter.approved"
+	ActionRecruiterRejected  = "recruiter.rejected"
+	ActionRecruiterCancelled = "recruiter.cancelled"
+	ActionCompanyCreated     = "company.created"
+	ActionJobPostCreated     = "job_post.created"
+	ActionJobPostDeleted     = "job_post.deleted"
+	ActionQuestionAdded      = "job_post.question_added"
+	ActionQuestionRemoved    = "job_post.question_removed"
+	ActionSkillsUpdated      = "applicant.skills_updated"
+	ActionProfileUpdated     = "applicant.profile_updated"
+	ActionApplicationCreated = "application.created"
+	ActionApplicationStaged  = "application.stage_changed"
+	ActionTokenCreated       = "token.created"
+	ActionTokenRevoked       = "token.revoked"
+	ActionWebhookCreated     = "webhook.created"
+	ActionWebhookDeleted     = "webhook.deleted"
+	ActionWebhookEnabled     = "webhook.enabled"
+	ActionWebhookRedelivered = "webhook.redelivered"
+	ActionUserRestored       = "user.restored"
+	ActionUserSuspended      = "user.suspended"
+	ActionUserUnsuspended    = "user.unsuspended"
+	ActionCompanyRestored    = "company.restored"
+	ActionJobPostRestored    = "job_post.restored"
+	ActionRetentionPurged    = "retention.purged"
+	ActionDataExported       = "account.data_exported"
+	ActionUsersExported      = "users.exported"
+	ActionDeletionRequested  = "account.deletion_requested"
+	ActionDeletionCancelled  = "account.deletion_cancelled"
+	ActionAccountAnonymized  = "account.anonymized"
+	// ActionRequest is recorded by Middleware for mutating requests whose
+	// handler did not record a more specific event.
+	ActionRequest = "request"
+)
+
+// Target types recorded in the audit log.
+const (
+	TargetUser        = "user"
+	TargetCompany     = "company"
+	TargetJobPost     = "job_post"
+	TargetApplication = "application"
+	TargetToken       = "token"
+	TargetWebhook     = "webhook"
+	TargetDelivery    = "webhook_delivery"
+	TargetRoute       = "route"
+	TargetSystem      = "system"
+)
+
+const recordedKey = "audit_recorded"
+
+type Recorder struct {
+	Queries *db.Queries
+}
+
+func NewRecorder(queries *db.Queries) *Recorder {
+	return &Recorder{Queries: queries}
+}
+
+// Record appends an event for the user making the request. before and after
+// are JSON snapshots of the target; pass nil when there is nothing to show.
+// Failures are logged rather than returned so auditing never breaks the
+// action being audited, and the insert outlives a cancelled request so a
+// completed action is never left unaudited.
+func (r *Recorder) Record(c *gin.Context, action, targetType, targetID string, before, after any) {
+	c.Set(recordedKey, true)
+
+	actorID, actorEmail := actor(c)
+	err := r.Queries.CreateAuditEvent(context.WithoutCancel(c.Request.Context()), db.CreateAuditEventParams{
+		ActorID:    actorID,
+		ActorEmail: actorEmail,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     snapshot(before),
+		After:      snapshot(after),
+		Ip:         sql.NullString{String: c.ClientIP(), Valid: c.ClientIP() != ""},
+		UserAgent:  sql.NullString{String: c.Request.UserAgent(), Valid: c.Request.UserAgent() != ""},
+	})
+	if err != nil {
+		logging.FromGin(c).Error("recording audit event", "action", action, "error", err)
+	}
+}
+
+// RecordSystem appends an event with no acting user, for background jobs.
+func (r *Recorder) RecordSystem(ctx context.Context, action, targetType, targetID string, before, after any) {
+	err := r.Queries.CreateAuditEvent(ctx, db.CreateAuditEventParams{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     snapshot(before),
+		After:      snapshot(after),
+	})
+	if err != nil {
+		logging.FromContext(ctx).Error("recording audit event", "action", action, "error", err)
+	}
+}
+
+// Middleware records a generic event for every successful mutating request
+// that did not call Record itself, so new handlers are never silently
+// unaudited.
+func (r *Recorder) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+		if c.Writer.Status() >= http.StatusBadRequest || c.GetBool(recordedKey) {
+			return
+		}
+		params := map[string]string{}
+		for _, p := range c.Params {
+			params[p.Key] = p.Value
+		}
+		r.Record(c, ActionRequest, TargetRoute, c.Request.Method+" "+c.FullPath(), nil, params)
+	}
+}
+
+// actor prefers the identity set by TokenAuthMiddleware and falls back to
+// the cookie session.
+func actor(c *gin.Context) (uuid.NullUUID, sql.NullString) {
+	id, email := c.GetString("id"), c.GetString("email")
+	if id == "" {
+		session := sessions.Default(c)
+		id, _ = session.Get("id").(string)
+		email, _ = session.Get("email").(string)
+	}
+	uid, err := uuid.Parse(id)
+	return uuid.NullUUID{UUID: uid, Valid: err == nil}, sql.NullString{String: email, Valid: email != ""}
+}
+
+func snapshot(v any) json.RawMessage {
+	if v == nil {
+		return json.RawMessage("{}")
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		slog.Error("encoding audit snapshot", "error", err)
+		return json.RawMessage("{}")
+	}
+	return b
+}
+
+// UserSnapshot returns the audited fields of a user.
+func UserSnapshot(u db.User) map[string]any {
+	snapshot := map[string]any{
+		"id":     u.ID,
+		"name":   u.Name,
+		"email":  u.Email,
+		"role":   u.Role,
+		"status": u.Status,
+	}
+	if u.Status == "suspended" {
+		snapshot["suspended_until"] = u.SuspendedUntil.Time
+		snapshot["suspension_reason"] = u.SuspensionReason.String
+	}
+	return snapshot
+}
+
+// CompanySnapshot returns the audited fields of a company.
+func CompanySnapshot(co db.Company) map[string]any {
+	return map[string]any{
+		"id":           co.ID,
+		"recruiter_id": co.RecruiterID.UUID,
+		"name":         co.Name,
+		"description":  co.Description.String,
+	}
+}
+
+// JobPostSnapshot returns the audited fields of a job posting.
+func JobPostSnapshot(p db.JobPosting) map[string]any {
+	return map[string]any{
+		"id":               p.ID,
+		"recruiter_id":     p.RecruiterID.UUID,
+		"company_id":       p.CompanyID.UUID,
+		"company_name":     p.CompanyName,
+		"position":         p.Position,
+		"skills":           p.Skills,
+		"preferred_skills": p.PreferredSkills,
+		"description":      p.Description.String,
+		"salary":           p.Salary.String,
+	}
+}
+
+// QuestionSnapshot returns the audited fields of a screening question.
+func QuestionSnapshot(q db.ScreeningQuestion) map[string]any {
+	snapshot := map[string]any{
+		"id":               q.ID,
+		"job_posting_id":   q.JobPostingID,
+		"prompt":           q.Prompt,
+		"kind":             q.Kind,
+		"options":          q.Options,
+		"required":         q.Required,
+		"knockout_answers": q.KnockoutAnswers,
+	}
+	if q.KnockoutMin.Valid {
+		snapshot["knockout_min"] = q.KnockoutMin.Int32
+	}
+	if q.KnockoutMax.Valid {
+		snapshot["knockout_max"] = q.KnockoutMax.Int32
+	}
+	return snapshot
+}
+
+// ApplicationSnapshot returns the audited fields of an application.
+func ApplicationSnapshot(a db.Application) map[string]any {
+	return map[string]any{
+		"id":             a.ID,
+		"job_posting_id": a.JobPostingID,
+		"applicant_id":   a.ApplicantID,
+		"stage":          a.Stage,
+		"source":         a.Source,
+	}
+}
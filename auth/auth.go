@@ -6,32 +6,55 @@ import (
 	"database/sql"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"gin-app/authz"
+	"gin-app/config"
 	db "gin-app/db/sqlc"
+	"gin-app/logging"
 	"io"
-	"log"
 	"net/http"
-	"os"
-	"strings"
+	"time"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/joho/godotenv"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 )
 
-var (
-	conf *oauth2.Config
-)
+// ErrSuspended means the user's suspension has not run out yet.
+var ErrSuspended = errors.New("account suspended")
 
 type Service struct {
-	Queries *db.Queries
+	Queries   *db.Queries
+	OAuth     *oauth2.Config
+	Redirects config.RedirectConfig
+}
+
+func NewService(queries *db.Queries, cfg *config.Config) *Service {
+	return &Service{
+		Queries: queries,
+		OAuth: &oauth2.Config{
+			ClientID:     cfg.Google.ClientID,
+			ClientSecret: cfg.Google.ClientSecret,
+			RedirectURL:  cfg.Google.RedirectURL,
+			Scopes: []string{
+				"https://www.googleapis.com/auth/userinfo.email",
+				"https://www.googleapis.com/auth/userinfo.profile",
+			},
+			Endpoint: google.Endpoint,
+		},
+		Redirects: cfg.Redirects,
+	}
 }
 
-func NewService(queries *db.Queries) *Service {
-	return &Service{Queries: queries}
+var ErrSessionRevoked = errors.New("session has been revoked")
+
+var tracedClient = &http.Client{
+	Transport: otelhttp.NewTransport(http.DefaultTransport),
+	Timeout:   10 * time.Second,
 }
 
 type User struct {
@@ -52,25 +75,6 @@ func randToken() string {
 	return base64.StdEncoding.EncodeToString(b)
 }
 
-func init() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Println(err.Error())
-		log.Fatal("Error loading .env file")
-	}
-
-	conf = &oauth2.Config{
-		ClientID:     os.Getenv("GOOGLE_CLIENT_ID"),
-		ClientSecret: os.Getenv("GOOGLE_CLIENT_SECRET"),
-		RedirectURL:  os.Getenv("GOOGLE_REDIRECT_URL"),
-		Scopes: []string{
-			"https://www.googleapis.com/auth/userinfo.email",
-			"https://www.googleapis.com/auth/userinfo.profile",
-		},
-		Endpoint: google.Endpoint,
-	}
-}
-
 func (s *Service) LoginHandler(c *gin.Context) {
 	state := randToken()
 	session := sessions.Default(c)
@@ -80,7 +84,7 @@ func (s *Service) LoginHandler(c *gin.Context) {
 	session.Set("role", role)
 
 	session.Save()
-	c.Redirect(http.StatusFound, conf.AuthCodeURL(state))
+	c.Redirect(http.StatusFound, s.OAuth.AuthCodeURL(state))
 }
 
 func (s *Service) AuthHandler(c *gin.Context) {
@@ -91,17 +95,25 @@ func (s *Service) AuthHandler(c *gin.Context) {
 		return
 	}
 
-	tok, err := conf.Exchange(context.Background(), c.Query("code"))
+	// Route the token exchange and userinfo call through a traced client so
+	// they show up as child spans of this request.
+	ctx := context.WithValue(c.Request.Context(), oauth2.HTTPClient, tracedClient)
+	tok, err := s.OAuth.Exchange(ctx, c.Query("code"))
 	if err != nil {
-		log.Printf("Error exchanging code: %v", err)
+		logging.FromGin(c).Error("exchanging OAuth code", "error", err)
 		c.AbortWithError(http.StatusBadRequest, err)
 		return
 	}
 
-	client := conf.Client(context.Background(), tok)
-	email, err := client.Get("https://www.googleapis.com/oauth2/v3/userinfo")
+	client := s.OAuth.Client(ctx, tok)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://www.googleapis.com/oauth2/v3/userinfo", nil)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	email, err := client.Do(req)
 	if err != nil {
-		log.Printf("Error getting user info: %v", err)
+		logging.FromGin(c).Error("fetching Google user info", "error", err)
 		c.AbortWithError(http.StatusBadRequest, err)
 		return
 	}
@@ -109,25 +121,31 @@ func (s *Service) AuthHandler(c *gin.Context) {
 
 	data, err := io.ReadAll(email.Body)
 	if err != nil {
-		log.Printf("Error reading response: %v", err)
+		logging.FromGin(c).Error("reading Google user info", "error", err)
 		c.AbortWithError(http.StatusBadRequest, err)
 		return
 	}
 
 	var user User
 	if err := json.Unmarshal(data, &user); err != nil {
-		log.Printf("Error unmarshaling user data: %v", err)
+		logging.FromGin(c).Error("decoding Google user info", "error", err)
 		c.AbortWithError(http.StatusBadRequest, err)
 		return
 	}
 
-	createdUser, err := s.Queries.GetUserByEmail(context.Background(), user.Email)
+	createdUser, err := s.Queries.GetUserByEmail(c.Request.Context(), user.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			role := session.Get("role")
-			if role == "recruiter" {
-				role = "pending"
-			} else if role == "admin" {
+			// Only applicants and recruiters can sign up; recruiters wait
+			// for an admin to approve them.
+			role, _ := session.Get("role").(string)
+			status := authz.StatusActive
+			switch role {
+			case "", "applicant":
+				role = "applicant"
+			case "recruiter":
+				status = authz.StatusPending
+			default:
 				c.Redirect(http.StatusFound, "/")
 				return
 			}
@@ -136,31 +154,51 @@ func (s *Service) AuthHandler(c *gin.Context) {
 				Name:    user.Name,
 				Email:   user.Email,
 				Picture: sql.NullString{String: user.Picture, Valid: true},
-				Role:    role.(string),
+				Role:    role,
+				Status:  status,
 			}
-			createdUser, err = s.Queries.CreateUser(context.Background(), params)
+			createdUser, err = s.Queries.CreateUser(c.Request.Context(), params)
 			if err != nil {
-				log.Printf("Error creating user: %v", err)
+				logging.FromGin(c).Error("creating user", "error", err)
 				c.AbortWithError(http.StatusInternalServerError, err)
 				return
 			}
-			if role == "pending" {
+			if status == authz.StatusPending {
 				session.Set("logo", createdUser.Picture.String)
 			}
 		} else {
-			log.Printf("Error getting user: %v", err)
+			logging.FromGin(c).Error("looking up user", "error", err)
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	if createdUser.Status == authz.StatusSuspended {
+		err := s.checkSuspension(c.Request.Context(), createdUser.ID, createdUser.SuspendedUntil)
+		if errors.Is(err, ErrSuspended) {
+			logging.FromGin(c).Info("blocked sign-in of suspended user", "user_id", createdUser.ID)
+			c.HTML(http.StatusForbidden, "suspended.html", gin.H{
+				"Title":  "Account suspended",
+				"Reason": createdUser.SuspensionReason.String,
+				"Until":  createdUser.SuspendedUntil,
+			})
+			return
+		}
+		if err != nil {
+			logging.FromGin(c).Error("lifting expired suspension", "error", err)
 			c.AbortWithError(http.StatusInternalServerError, err)
 			return
 		}
+		createdUser.Status = authz.StatusActive
 	}
 
 	rand_tok := randToken()
-	_, err = s.Queries.CreateOrUpdateSession(context.Background(), db.CreateOrUpdateSessionParams{
+	_, err = s.Queries.CreateOrUpdateSession(c.Request.Context(), db.CreateOrUpdateSessionParams{
 		UserID: uuid.NullUUID{UUID: createdUser.ID, Valid: true},
 		Token:  rand_tok,
 	})
 	if err != nil {
-		log.Printf("Error creating/updating session: %v", err)
+		logging.FromGin(c).Error("creating session", "error", err)
 		c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
@@ -168,16 +206,37 @@ func (s *Service) AuthHandler(c *gin.Context) {
 	session.Set("email", createdUser.Email)
 	session.Set("name", createdUser.Name)
 	session.Set("role", createdUser.Role)
+	session.Set("status", createdUser.Status)
 	session.Set("id", createdUser.ID.String())
 	session.Set("picture", createdUser.Picture.String)
 	session.Set("token", rand_tok)
 	if err := session.Save(); err != nil {
-		log.Printf("Error saving session: %v", err)
+		logging.FromGin(c).Error("saving session", "error", err)
 		c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
 
-	c.Redirect(http.StatusFound, os.Getenv(strings.ToUpper(createdUser.Role)+"_REDIRECT_URL"))
+	c.Redirect(http.StatusFound, s.Redirects.ForUser(createdUser.Role, createdUser.Status))
+}
+
+// checkSuspension fails with ErrSuspended while a suspension lasts. One that
+// has run out is lifted now rather than in a worker.
+func (s *Service) checkSuspension(ctx context.Context, id uuid.UUID, until sql.NullTime) error {
+	if !until.Valid || until.Time.After(time.Now()) {
+		return ErrSuspended
+	}
+	return s.Queries.UnsuspendUser(ctx, id)
+}
+
+// SessionUser returns the current role and status of the user a cookie
+// session token belongs to, or ErrSessionRevoked once the session was deleted
+// by signing out, rejection, suspension or account deletion.
+func (s *Service) SessionUser(ctx context.Context, token string) (db.GetSessionUserRow, error) {
+	row, err := s.Queries.GetSessionUser(ctx, token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return row, ErrSessionRevoked
+	}
+	return row, err
 }
 
 func (s *Service) LogoutHandler(c *gin.Context) {
@@ -187,26 +246,26 @@ func (s *Service) LogoutHandler(c *gin.Context) {
 	email := session.Get("email")
 
 	if token != nil && email != nil {
-		user, err := s.Queries.GetUserByEmail(context.Background(), email.(string))
+		user, err := s.Queries.GetUserByEmail(c.Request.Context(), email.(string))
 		if err != nil {
-			log.Printf("Error getting user during logout: %v", err)
+			logging.FromGin(c).Warn("looking up user during logout", "error", err)
 		} else {
-			err = s.Queries.DeleteSession(context.Background(), db.DeleteSessionParams{
+			err = s.Queries.DeleteSession(c.Request.Context(), db.DeleteSessionParams{
 				UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
 				Token:  token.(string),
 			})
 			if err != nil {
-				log.Printf("Error deleting session: %v", err)
+				logging.FromGin(c).Warn("deleting session", "error", err)
 			}
 		}
 	}
 
 	session.Clear()
 	if err := session.Save(); err != nil {
-		log.Printf("Error saving session during logout: %v", err)
+		logging.FromGin(c).Error("saving session during logout", "error", err)
 		c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
 
-	c.Redirect(http.StatusFound, os.Getenv("LOGOUT_REDIRECT_URL"))
+	c.Redirect(http.StatusFound, s.Redirects.Logout)
 }
@@ -0,0 +1,92 @@
+package authz
+
+import (
+	"context"
+	db "gin-app/db/sqlc"
+	"sync"
+	"time"
+)
+
+// Permissions. Roles are granted them through the role_permissions table;
+// the names double as personal access token scopes.
+const (
+	JobsRead          = "jobs:read"
+	JobsWrite         = "jobs:write"
+	JobsApply         = "jobs:apply"
+	ProfileRead       = "profile:read"
+	ProfileWrite      = "profile:write"
+	AccountManage     = "account:manage"
+	WebhooksManage    = "webhooks:manage"
+	TokensManage      = "tokens:manage"
+	UsersRead         = "users:read"
+	UsersRestore      = "users:restore"
+	UsersSuspend      = "users:suspend"
+	RecruitersApprove = "recruiters:approve"
+	AuditRead         = "audit:read"
+	AnalyticsRead     = "analytics:read"
+	MetricsRead       = "metrics:read"
+)
+
+// Account statuses. Only active accounts hold their role's permissions: a
+// pending recruiter can finish onboarding and nothing else, and a suspended
+// account can do nothing.
+const (
+	StatusActive    = "active"
+	StatusPending   = "pending"
+	StatusSuspended = "suspended"
+)
+
+// Set is a set of permissions.
+type Set map[string]bool
+
+// Has reports whether the set holds every one of perms.
+func (s Set) Has(perms ...string) bool {
+	for _, p := range perms {
+		if !s[p] {
+			return false
+		}
+	}
+	return true
+}
+
+// Store caches the permissions of every role, reloading them after TTL so
+// changes to role_permissions apply without a restart.
+type Store struct {
+	Queries *db.Queries
+	TTL     time.Duration
+
+	mu       sync.Mutex
+	roles    map[string]Set
+	loadedAt time.Time
+}
+
+func NewStore(queries *db.Queries) *Store {
+	return &Store{Queries: queries, TTL: time.Minute}
+}
+
+// Permissions returns the permissions granted to role. An unknown role has
+// none. If a reload fails, the previously loaded permissions are kept.
+func (s *Store) Permissions(ctx context.Context, role string) (Set, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.roles == nil || time.Since(s.loadedAt) > s.TTL {
+		rows, err := s.Queries.ListRolePermissions(ctx)
+		if err != nil {
+			if s.roles == nil {
+				return nil, err
+			}
+			return s.roles[role], nil
+		}
+		roles := make(map[string]Set)
+		for _, row := range rows {
+			if roles[row.Role] == nil {
+				roles[row.Role] = make(Set)
+			}
+			roles[row.Role][row.Permission] = true
+		}
+		s.roles = roles
+		s.loadedAt = time.Now()
+	}
+	return s.roles[role], nil
+}
@@ -0,0 +1,540 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds every setting the app reads at startup. Values come from, in
+// increasing precedence: the defaults below, an optional YAML file named by
+// CONFIG_FILE, an optional .env file, and the process environment.
+type Config struct {
+	Host          string `yaml:"host"`
+	Port          string `yaml:"port"`
+	GinMode       string `yaml:"gin_mode"`
+	SessionSecret string `yaml:"session_secret"`
+
+	Database  DatabaseConfig `yaml:"database"`
+	HTTP      HTTPConfig     `yaml:"http"`
+	Log       LogConfig      `yaml:"log"`
+	Tracing   TracingConfig  `yaml:"tracing"`
+	Security  SecurityConfig `yaml:"security"`
+	Google    GoogleConfig   `yaml:"google"`
+	Redirects RedirectConfig `yaml:"redirects"`
+	Mail      MailConfig     `yaml:"mail"`
+	Metrics   MetricsConfig  `yaml:"metrics"`
+
+	SoftDeleteRetentionDays  int `yaml:"soft_delete_retention_days"`
+	AccountDeletionGraceDays int `yaml:"account_deletion_grace_days"`
+	// AnalyticsRefreshInterval is how often the admin analytics'
+	// materialized views are refreshed.
+	AnalyticsRefreshInterval time.Duration `yaml:"analytics_refresh_interval"`
+	// JobAlertInterval is how often saved searches are matched against
+	// newly published postings.
+	JobAlertInterval time.Duration `yaml:"job_alert_interval"`
+}
+
+// DatabaseConfig holds the connection string and sql.DB pool settings.
+type DatabaseConfig struct {
+	URL             string        `yaml:"url"`
+	AutoMigrate     bool          `yaml:"auto_migrate"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+}
+
+// HTTPConfig holds the server timeouts. RequestTimeout bounds each request's
+// context and so every query it makes; ShutdownTimeout bounds how long a
+// SIGTERM waits for in-flight requests and background workers to finish.
+type HTTPConfig struct {
+	RequestTimeout  time.Duration `yaml:"request_timeout"`
+	ReadTimeout     time.Duration `yaml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// LogConfig controls the JSON logger. With Redact set, emails, tokens and
+// secrets in log attributes are masked.
+type LogConfig struct {
+	Level  string `yaml:"level"`
+	Redact bool   `yaml:"redact"`
+}
+
+// TracingConfig selects the OpenTelemetry exporter: "none", "stdout" for
+// local development, or "otlp".
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter"`
+	ServiceName string  `yaml:"service_name"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// SecurityConfig holds the response headers and request size limits applied
+// to every route, and the rate limits for sign-in, applying and uploads.
+// TrustedProxies lists the proxy addresses whose X-Forwarded-For is believed;
+// with none, the client IP is the connection's remote address.
+type SecurityConfig struct {
+	ContentSecurityPolicy string          `yaml:"content_security_policy"`
+	HSTSMaxAge            time.Duration   `yaml:"hsts_max_age"`
+	MaxBodyBytes          int64           `yaml:"max_body_bytes"`
+	MaxUploadBytes        int64           `yaml:"max_upload_bytes"`
+	TrustedProxies        []string        `yaml:"trusted_proxies"`
+	RateLimit             RateLimitConfig `yaml:"rate_limit"`
+}
+
+// RateLimitConfig selects where buckets live: "memory" keeps them per
+// process, "postgres" shares them between replicas.
+type RateLimitConfig struct {
+	Store  string    `yaml:"store"`
+	Auth   RateLimit `yaml:"auth"`
+	Apply  RateLimit `yaml:"apply"`
+	Upload RateLimit `yaml:"upload"`
+}
+
+// RateLimit is a token bucket holding up to Burst requests and refilled at
+// PerMinute. It applies separately to each client IP and each signed-in user.
+type RateLimit struct {
+	PerMinute float64 `yaml:"per_minute"`
+	Burst     int     `yaml:"burst"`
+}
+
+type GoogleConfig struct {
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+	RedirectURL  string `yaml:"redirect_url"`
+}
+
+// RedirectConfig is where users land after signing in, by role, and after
+// signing out.
+type RedirectConfig struct {
+	Admin     string `yaml:"admin"`
+	Recruiter string `yaml:"recruiter"`
+	Applicant string `yaml:"applicant"`
+	Pending   string `yaml:"pending"`
+	Logout    string `yaml:"logout"`
+}
+
+// MailConfig is the SMTP server notifications are emailed through. With no
+// SMTPHost, notifications are only shown in the dashboard. BaseURL is the
+// public address of the app, used for links in emails.
+type MailConfig struct {
+	SMTPHost string `yaml:"smtp_host"`
+	SMTPPort string `yaml:"smtp_port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+	BaseURL  string `yaml:"base_url"`
+}
+
+// MetricsConfig protects /metrics, which reveals traffic, query and business
+// figures. With Addr set it is served only on that separate listener, for
+// the monitoring network to reach; otherwise on the main one. A set Token
+// must be sent by scrapers as a bearer token, and without Addr it is
+// required: with neither, /metrics is not served at all.
+type MetricsConfig struct {
+	Addr  string `yaml:"addr"`
+	Token string `yaml:"token"`
+}
+
+// Addr returns the address the HTTP server listens on.
+func (c *Config) Addr() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+// ForUser returns the post sign-in redirect for a role and account status.
+// Pending accounts land on onboarding whatever their role.
+func (r RedirectConfig) ForUser(role, status string) string {
+	if status == "pending" {
+		return r.Pending
+	}
+	switch role {
+	case "admin":
+		return r.Admin
+	case "recruiter":
+		return r.Recruiter
+	default:
+		return r.Applicant
+	}
+}
+
+// ValidationError lists every problem found in a loaded config, so a bad
+// deployment can be fixed in one pass.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+func defaults() *Config {
+	return &Config{
+		Port:    "8080",
+		GinMode: "debug",
+		Database: DatabaseConfig{
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		HTTP: HTTPConfig{
+			RequestTimeout:  10 * time.Second,
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			ShutdownTimeout: 30 * time.Second,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Redact: true,
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "gin-app",
+			SampleRatio: 1,
+		},
+		Security: SecurityConfig{
+			ContentSecurityPolicy: "default-src 'self'; " +
+				"script-src 'self' 'unsafe-inline' https://oss.maxcdn.com; " +
+				"style-src 'self' 'unsafe-inline' https://fonts.googleapis.com; " +
+				"font-src 'self' https://fonts.gstatic.com; " +
+				"img-src 'self' data: https:; " +
+				"form-action 'self'; frame-ancestors 'none'; base-uri 'self'",
+			HSTSMaxAge:     365 * 24 * time.Hour,
+			MaxBodyBytes:   1 << 20,
+			MaxUploadBytes: 10 << 20,
+			RateLimit: RateLimitConfig{
+				Store:  "memory",
+				Auth:   RateLimit{PerMinute: 10, Burst: 20},
+				Apply:  RateLimit{PerMinute: 5, Burst: 10},
+				Upload: RateLimit{PerMinute: 2, Burst: 5},
+			},
+		},
+		Redirects: RedirectConfig{
+			Admin:     "/admin/dashboard",
+			Recruiter: "/recruiter/dashboard",
+			Applicant: "/applicant/dashboard",
+			Pending:   "/recruiter/create-company",
+			Logout:    "/",
+		},
+		SoftDeleteRetentionDays:  30,
+		AccountDeletionGraceDays: 14,
+		AnalyticsRefreshInterval: 15 * time.Minute,
+		JobAlertInterval:         time.Minute,
+		Mail: MailConfig{
+			SMTPPort: "587",
+			BaseURL:  "http://localhost:8080",
+		},
+	}
+}
+
+// Load reads and validates the configuration.
+func Load() (*Config, error) {
+	cfg, err := read()
+	if err != nil {
+		return nil, err
+	}
+	var e env
+	e.str("HOST", &cfg.Host)
+	e.str("PORT", &cfg.Port)
+	e.duration("HTTP_REQUEST_TIMEOUT", &cfg.HTTP.RequestTimeout)
+	e.duration("HTTP_READ_TIMEOUT", &cfg.HTTP.ReadTimeout)
+	e.duration("HTTP_WRITE_TIMEOUT", &cfg.HTTP.WriteTimeout)
+	e.duration("HTTP_IDLE_TIMEOUT", &cfg.HTTP.IdleTimeout)
+	e.duration("SHUTDOWN_TIMEOUT", &cfg.HTTP.ShutdownTimeout)
+	e.str("GIN_MODE", &cfg.GinMode)
+	e.str("CONTENT_SECURITY_POLICY", &cfg.Security.ContentSecurityPolicy)
+	e.duration("HSTS_MAX_AGE", &cfg.Security.HSTSMaxAge)
+	e.integer64("MAX_BODY_BYTES", &cfg.Security.MaxBodyBytes)
+	e.integer64("MAX_UPLOAD_BYTES", &cfg.Security.MaxUploadBytes)
+	if v, ok := os.LookupEnv("TRUSTED_PROXIES"); ok {
+		cfg.Security.TrustedProxies = nil
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				cfg.Security.TrustedProxies = append(cfg.Security.TrustedProxies, p)
+			}
+		}
+	}
+	e.str("RATE_LIMIT_STORE", &cfg.Security.RateLimit.Store)
+	e.float("RATE_LIMIT_AUTH_PER_MINUTE", &cfg.Security.RateLimit.Auth.PerMinute)
+	e.integer("RATE_LIMIT_AUTH_BURST", &cfg.Security.RateLimit.Auth.Burst)
+	e.float("RATE_LIMIT_APPLY_PER_MINUTE", &cfg.Security.RateLimit.Apply.PerMinute)
+	e.integer("RATE_LIMIT_APPLY_BURST", &cfg.Security.RateLimit.Apply.Burst)
+	e.float("RATE_LIMIT_UPLOAD_PER_MINUTE", &cfg.Security.RateLimit.Upload.PerMinute)
+	e.integer("RATE_LIMIT_UPLOAD_BURST", &cfg.Security.RateLimit.Upload.Burst)
+	e.str("LOG_LEVEL", &cfg.Log.Level)
+	e.str("OTEL_TRACES_EXPORTER", &cfg.Tracing.Exporter)
+	e.str("OTEL_SERVICE_NAME", &cfg.Tracing.ServiceName)
+	e.float("OTEL_TRACES_SAMPLE_RATIO", &cfg.Tracing.SampleRatio)
+	e.boolean("LOG_REDACT", &cfg.Log.Redact)
+	e.database(&cfg.Database)
+	e.str("SESSION_SECRET", &cfg.SessionSecret)
+	e.str("GOOGLE_CLIENT_ID", &cfg.Google.ClientID)
+	e.str("GOOGLE_CLIENT_SECRET", &cfg.Google.ClientSecret)
+	e.str("GOOGLE_REDIRECT_URL", &cfg.Google.RedirectURL)
+	e.str("ADMIN_REDIRECT_URL", &cfg.Redirects.Admin)
+	e.str("RECRUITER_REDIRECT_URL", &cfg.Redirects.Recruiter)
+	e.str("APPLICANT_REDIRECT_URL", &cfg.Redirects.Applicant)
+	e.str("PENDING_REDIRECT_URL", &cfg.Redirects.Pending)
+	e.str("LOGOUT_REDIRECT_URL", &cfg.Redirects.Logout)
+	e.integer("SOFT_DELETE_RETENTION_DAYS", &cfg.SoftDeleteRetentionDays)
+	e.integer("ACCOUNT_DELETION_GRACE_DAYS", &cfg.AccountDeletionGraceDays)
+	e.duration("ANALYTICS_REFRESH_INTERVAL", &cfg.AnalyticsRefreshInterval)
+	e.duration("JOB_ALERT_INTERVAL", &cfg.JobAlertInterval)
+	e.str("SMTP_HOST", &cfg.Mail.SMTPHost)
+	e.str("SMTP_PORT", &cfg.Mail.SMTPPort)
+	e.str("SMTP_USERNAME", &cfg.Mail.Username)
+	e.str("SMTP_PASSWORD", &cfg.Mail.Password)
+	e.str("MAIL_FROM", &cfg.Mail.From)
+	e.str("BASE_URL", &cfg.Mail.BaseURL)
+	e.str("METRICS_ADDR", &cfg.Metrics.Addr)
+	e.str("METRICS_TOKEN", &cfg.Metrics.Token)
+
+	problems := append(e.problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+	return cfg, nil
+}
+
+// LoadDatabase reads and validates only the database settings, from the
+// same sources as Load. It is for commands such as migrate that must work
+// before the rest of the configuration is in place.
+func LoadDatabase() (DatabaseConfig, error) {
+	cfg, err := read()
+	if err != nil {
+		return DatabaseConfig{}, err
+	}
+	var e env
+	e.database(&cfg.Database)
+	problems := append(e.problems, cfg.Database.validate()...)
+	if len(problems) > 0 {
+		return DatabaseConfig{}, &ValidationError{Problems: problems}
+	}
+	return cfg.Database, nil
+}
+
+// read returns the defaults overlaid with the YAML file, and loads .env
+// into the environment for the env methods to pick up.
+func read() (*Config, error) {
+	cfg := defaults()
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading config file: %w", err)
+		}
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+		}
+	}
+
+	// .env is a development convenience; production sets real variables.
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("loading .env: %w", err)
+	}
+	return cfg, nil
+}
+
+// env overrides settings from environment variables, collecting a problem
+// for each value that does not parse.
+type env struct {
+	problems []string
+}
+
+func (e *env) str(key string, dst *string) {
+	if v, ok := os.LookupEnv(key); ok {
+		*dst = v
+	}
+}
+
+func (e *env) integer(key string, dst *int) {
+	if v, ok := os.LookupEnv(key); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			e.problems = append(e.problems, fmt.Sprintf("%s must be an integer, got %q", key, v))
+			return
+		}
+		*dst = n
+	}
+}
+
+func (e *env) integer64(key string, dst *int64) {
+	if v, ok := os.LookupEnv(key); ok {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			e.problems = append(e.problems, fmt.Sprintf("%s must be an integer, got %q", key, v))
+			return
+		}
+		*dst = n
+	}
+}
+
+func (e *env) duration(key string, dst *time.Duration) {
+	if v, ok := os.LookupEnv(key); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			e.problems = append(e.problems, fmt.Sprintf("%s must be a duration such as 30s, got %q", key, v))
+			return
+		}
+		*dst = d
+	}
+}
+
+func (e *env) float(key string, dst *float64) {
+	if v, ok := os.LookupEnv(key); ok {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			e.problems = append(e.problems, fmt.Sprintf("%s must be a number, got %q", key, v))
+			return
+		}
+		*dst = f
+	}
+}
+
+func (e *env) boolean(key string, dst *bool) {
+	if v, ok := os.LookupEnv(key); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			e.problems = append(e.problems, fmt.Sprintf("%s must be true or false, got %q", key, v))
+			return
+		}
+		*dst = b
+	}
+}
+
+func (e *env) database(d *DatabaseConfig) {
+	e.str("DATABASE_URL", &d.URL)
+	e.boolean("AUTO_MIGRATE", &d.AutoMigrate)
+	e.integer("DB_MAX_OPEN_CONNS", &d.MaxOpenConns)
+	e.integer("DB_MAX_IDLE_CONNS", &d.MaxIdleConns)
+	e.duration("DB_CONN_MAX_LIFETIME", &d.ConnMaxLifetime)
+	e.duration("DB_CONN_MAX_IDLE_TIME", &d.ConnMaxIdleTime)
+}
+
+func (c *Config) validate() []string {
+	problems := c.Database.validate()
+	required := []struct{ key, value string }{
+		{"SESSION_SECRET", c.SessionSecret},
+		{"GOOGLE_CLIENT_ID", c.Google.ClientID},
+		{"GOOGLE_CLIENT_SECRET", c.Google.ClientSecret},
+		{"GOOGLE_REDIRECT_URL", c.Google.RedirectURL},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			problems = append(problems, r.key+" is required")
+		}
+	}
+	if c.GinMode == "release" && c.SessionSecret != "" && len(c.SessionSecret) < 32 {
+		problems = append(problems, "SESSION_SECRET must be at least 32 bytes in release mode")
+	}
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, fmt.Sprintf("LOG_LEVEL must be debug, info, warn or error, got %q", c.Log.Level))
+	}
+	switch c.Tracing.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		problems = append(problems, fmt.Sprintf("OTEL_TRACES_EXPORTER must be none, stdout or otlp, got %q", c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, "OTEL_TRACES_SAMPLE_RATIO must be between 0 and 1")
+	}
+	switch c.GinMode {
+	case "debug", "release", "test":
+	default:
+		problems = append(problems, fmt.Sprintf("GIN_MODE must be debug, release or test, got %q", c.GinMode))
+	}
+	if _, err := strconv.Atoi(c.Port); err != nil {
+		problems = append(problems, fmt.Sprintf("PORT must be a number, got %q", c.Port))
+	}
+	if c.HTTP.RequestTimeout <= 0 {
+		problems = append(problems, "HTTP_REQUEST_TIMEOUT must be positive")
+	}
+	if c.HTTP.ShutdownTimeout <= 0 {
+		problems = append(problems, "SHUTDOWN_TIMEOUT must be positive")
+	}
+	if c.Security.HSTSMaxAge < 0 {
+		problems = append(problems, "HSTS_MAX_AGE must not be negative")
+	}
+	if c.Security.MaxBodyBytes <= 0 || c.Security.MaxUploadBytes <= 0 {
+		problems = append(problems, "MAX_BODY_BYTES and MAX_UPLOAD_BYTES must be positive")
+	}
+	switch c.Security.RateLimit.Store {
+	case "memory", "postgres":
+	default:
+		problems = append(problems, fmt.Sprintf("RATE_LIMIT_STORE must be memory or postgres, got %q", c.Security.RateLimit.Store))
+	}
+	limits := []struct {
+		name  string
+		limit RateLimit
+	}{
+		{"AUTH", c.Security.RateLimit.Auth},
+		{"APPLY", c.Security.RateLimit.Apply},
+		{"UPLOAD", c.Security.RateLimit.Upload},
+	}
+	for _, l := range limits {
+		if l.limit.PerMinute <= 0 || l.limit.Burst < 1 {
+			problems = append(problems, fmt.Sprintf("RATE_LIMIT_%s_PER_MINUTE must be positive and RATE_LIMIT_%s_BURST at least 1", l.name, l.name))
+		}
+	}
+	if c.SoftDeleteRetentionDays < 1 {
+		problems = append(problems, "SOFT_DELETE_RETENTION_DAYS must be at least 1")
+	}
+	if c.AccountDeletionGraceDays < 0 {
+		problems = append(problems, "ACCOUNT_DELETION_GRACE_DAYS must not be negative")
+	}
+	if c.AnalyticsRefreshInterval <= 0 {
+		problems = append(problems, "ANALYTICS_REFRESH_INTERVAL must be positive")
+	}
+	if c.JobAlertInterval <= 0 {
+		problems = append(problems, "JOB_ALERT_INTERVAL must be positive")
+	}
+	if c.Mail.SMTPHost != "" {
+		if c.Mail.From == "" {
+			problems = append(problems, "MAIL_FROM is required when SMTP_HOST is set")
+		}
+		if _, err := strconv.Atoi(c.Mail.SMTPPort); err != nil {
+			problems = append(problems, fmt.Sprintf("SMTP_PORT must be a number, got %q", c.Mail.SMTPPort))
+		}
+	}
+	if c.Metrics.Addr != "" {
+		if _, port, err := net.SplitHostPort(c.Metrics.Addr); err != nil {
+			problems = append(problems, fmt.Sprintf("METRICS_ADDR must be a host:port address, got %q", c.Metrics.Addr))
+		} else if port == c.Port {
+			problems = append(problems, "METRICS_ADDR must use a different port from PORT")
+		}
+	}
+	if c.Metrics.Token != "" && len(c.Metrics.Token) < 16 {
+		problems = append(problems, "METRICS_TOKEN must be at least 16 bytes")
+	}
+	if u, err := url.Parse(c.Mail.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Sprintf("BASE_URL must be an http or https URL, got %q", c.Mail.BaseURL))
+	}
+	return problems
+}
+
+func (d DatabaseConfig) validate() []string {
+	var problems []string
+	if strings.TrimSpace(d.URL) == "" {
+		problems = append(problems, "DATABASE_URL is required")
+	}
+	if d.MaxOpenConns < 0 || d.MaxIdleConns < 0 {
+		problems = append(problems, "DB_MAX_OPEN_CONNS and DB_MAX_IDLE_CONNS must not be negative")
+	}
+	if d.MaxOpenConns > 0 && d.MaxIdleConns > d.MaxOpenConns {
+		problems = append(problems, "DB_MAX_IDLE_CONNS must not exceed DB_MAX_OPEN_CONNS")
+	}
+	return problems
+}
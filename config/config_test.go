@@ -0,0 +1,205 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// valid returns the defaults with every required setting filled in.
+func valid() *Config {
+	c := defaults()
+	c.Database.URL = "postgres://localhost/app"
+	c.SessionSecret = "0123456789abcdef0123456789abcdef"
+	c.Google.ClientID = "client-id"
+	c.Google.ClientSecret = "client-secret"
+	c.Google.RedirectURL = "http://localhost:8080/auth/google/callback"
+	return c
+}
+
+func TestValidate(t *testing.T) {
+	if problems := valid().validate(); len(problems) > 0 {
+		t.Fatalf("valid config has problems: %q", problems)
+	}
+
+	tests := []struct {
+		name   string
+		change func(*Config)
+		want   []string
+	}{
+		{"missing required", func(c *Config) {
+			c.Database.URL = ""
+			c.SessionSecret = " "
+		}, []string{"DATABASE_URL is required", "SESSION_SECRET is required"}},
+		{"short secret in release", func(c *Config) {
+			c.GinMode = "release"
+			c.SessionSecret = "short"
+		}, []string{"SESSION_SECRET must be at least 32 bytes in release mode"}},
+		{"short secret in debug", func(c *Config) { c.SessionSecret = "short" }, nil},
+		{"log level", func(c *Config) { c.Log.Level = "verbose" }, []string{`LOG_LEVEL must be debug, info, warn or error, got "verbose"`}},
+		{"log level case", func(c *Config) { c.Log.Level = "WARN" }, nil},
+		{"tracing", func(c *Config) {
+			c.Tracing.Exporter = "jaeger"
+			c.Tracing.SampleRatio = 1.5
+		}, []string{`OTEL_TRACES_EXPORTER must be none, stdout or otlp, got "jaeger"`, "OTEL_TRACES_SAMPLE_RATIO must be between 0 and 1"}},
+		{"gin mode", func(c *Config) { c.GinMode = "prod" }, []string{`GIN_MODE must be debug, release or test, got "prod"`}},
+		{"port", func(c *Config) { c.Port = "http" }, []string{`PORT must be a number, got "http"`}},
+		{"pool", func(c *Config) {
+			c.Database.MaxOpenConns = 5
+			c.Database.MaxIdleConns = 10
+		}, []string{"DB_MAX_IDLE_CONNS must not exceed DB_MAX_OPEN_CONNS"}},
+		{"unlimited pool", func(c *Config) {
+			c.Database.MaxOpenConns = 0
+			c.Database.MaxIdleConns = 10
+		}, nil},
+		{"timeouts", func(c *Config) {
+			c.HTTP.RequestTimeout = 0
+			c.HTTP.ShutdownTimeout = -time.Second
+		}, []string{"HTTP_REQUEST_TIMEOUT must be positive", "SHUTDOWN_TIMEOUT must be positive"}},
+		{"rate limits", func(c *Config) {
+			c.Security.RateLimit.Store = "redis"
+			c.Security.RateLimit.Apply.Burst = 0
+		}, []string{`RATE_LIMIT_STORE must be memory or postgres, got "redis"`, "RATE_LIMIT_APPLY_PER_MINUTE must be positive and RATE_LIMIT_APPLY_BURST at least 1"}},
+		{"retention", func(c *Config) {
+			c.SoftDeleteRetentionDays = 0
+			c.AccountDeletionGraceDays = -1
+		}, []string{"SOFT_DELETE_RETENTION_DAYS must be at least 1", "ACCOUNT_DELETION_GRACE_DAYS must not be negative"}},
+		{"mail", func(c *Config) {
+			c.Mail.SMTPHost = "smtp.example.com"
+			c.Mail.SMTPPort = "smtp"
+		}, []string{"MAIL_FROM is required when SMTP_HOST is set", `SMTP_PORT must be a number, got "smtp"`}},
+		{"base URL", func(c *Config) { c.Mail.BaseURL = "example.com" }, []string{`BASE_URL must be an http or https URL, got "example.com"`}},
+		{"metrics address", func(c *Config) { c.Metrics.Addr = "9090" }, []string{`METRICS_ADDR must be a host:port address, got "9090"`}},
+		{"metrics on main port", func(c *Config) { c.Metrics.Addr = ":8080" }, []string{"METRICS_ADDR must use a different port from PORT"}},
+		{"short metrics token", func(c *Config) { c.Metrics.Token = "secret" }, []string{"METRICS_TOKEN must be at least 16 bytes"}},
+	}
+	for _, tt := range tests {
+		c := valid()
+		tt.change(c)
+		if got := c.validate(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: problems = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// setenv sets key for the test, or unsets it if value is empty, and restores
+// it afterwards. Keys Load may set from .env are cleared this way too.
+func setenv(t *testing.T, key, value string) {
+	t.Setenv(key, value)
+	if value == "" {
+		os.Unsetenv(key)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	yaml := `
+port: "8000"
+gin_mode: release
+database:
+  max_open_conns: 40
+log:
+  level: warn
+`
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(yaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	env := "PORT=8001\nLOG_LEVEL=error\nGOOGLE_CLIENT_SECRET=from-dotenv\n"
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(env), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"GIN_MODE", "DB_MAX_OPEN_CONNS", "DB_MAX_IDLE_CONNS", "LOG_LEVEL", "GOOGLE_CLIENT_SECRET"} {
+		setenv(t, key, "")
+	}
+	setenv(t, "CONFIG_FILE", filepath.Join(dir, "config.yaml"))
+	setenv(t, "PORT", "8002")
+	setenv(t, "DATABASE_URL", "postgres://localhost/app")
+	setenv(t, "SESSION_SECRET", "0123456789abcdef0123456789abcdef")
+	setenv(t, "GOOGLE_CLIENT_ID", "client-id")
+	setenv(t, "GOOGLE_REDIRECT_URL", "http://localhost:8080/auth/google/callback")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		setting   string
+		got, want any
+	}{
+		{"PORT from the environment over .env and YAML", cfg.Port, "8002"},
+		{"LOG_LEVEL from .env over YAML", cfg.Log.Level, "error"},
+		{"GIN_MODE from YAML over the default", cfg.GinMode, "release"},
+		{"DB_MAX_OPEN_CONNS from YAML", cfg.Database.MaxOpenConns, 40},
+		{"DB_MAX_IDLE_CONNS from the default", cfg.Database.MaxIdleConns, 10},
+		{"GOOGLE_CLIENT_SECRET from .env", cfg.Google.ClientSecret, "from-dotenv"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.setting, tt.got, tt.want)
+		}
+	}
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	t.Chdir(t.TempDir())
+	for _, key := range []string{"CONFIG_FILE", "DATABASE_URL", "SESSION_SECRET", "GOOGLE_CLIENT_ID", "GOOGLE_CLIENT_SECRET", "GOOGLE_REDIRECT_URL", "LOG_LEVEL", "GIN_MODE"} {
+		setenv(t, key, "")
+	}
+	setenv(t, "PORT", "8080")
+	setenv(t, "HTTP_REQUEST_TIMEOUT", "ten seconds")
+	setenv(t, "DB_MAX_OPEN_CONNS", "many")
+
+	_, err := Load()
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Load returned %v, want a ValidationError", err)
+	}
+	want := []string{
+		`HTTP_REQUEST_TIMEOUT must be a duration such as 30s, got "ten seconds"`,
+		`DB_MAX_OPEN_CONNS must be an integer, got "many"`,
+		"DATABASE_URL is required",
+		"SESSION_SECRET is required",
+		"GOOGLE_CLIENT_ID is required",
+		"GOOGLE_CLIENT_SECRET is required",
+		"GOOGLE_REDIRECT_URL is required",
+	}
+	if !slices.Equal(invalid.Problems, want) {
+		t.Errorf("problems = %q\nwant %q", invalid.Problems, want)
+	}
+}
+
+func TestLoadDatabase(t *testing.T) {
+	t.Chdir(t.TempDir())
+	for _, key := range []string{"CONFIG_FILE", "SESSION_SECRET", "GOOGLE_CLIENT_ID", "GOOGLE_CLIENT_SECRET", "GOOGLE_REDIRECT_URL", "DB_MAX_IDLE_CONNS"} {
+		setenv(t, key, "")
+	}
+	setenv(t, "DATABASE_URL", "postgres://localhost/app")
+	setenv(t, "DB_MAX_OPEN_CONNS", "40")
+	setenv(t, "HTTP_REQUEST_TIMEOUT", "ten seconds")
+	setenv(t, "LOG_LEVEL", "verbose")
+
+	db, err := LoadDatabase()
+	if err != nil {
+		t.Fatalf("LoadDatabase without the other settings: %v", err)
+	}
+	if db.URL != "postgres://localhost/app" || db.MaxOpenConns != 40 {
+		t.Errorf("LoadDatabase = %+v, want the URL and pool size from the environment", db)
+	}
+
+	setenv(t, "DATABASE_URL", "")
+	setenv(t, "DB_MAX_OPEN_CONNS", "many")
+	_, err = LoadDatabase()
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("LoadDatabase returned %v, want a ValidationError", err)
+	}
+	want := []string{`DB_MAX_OPEN_CONNS must be an integer, got "many"`, "DATABASE_URL is required"}
+	if !slices.Equal(invalid.Problems, want) {
+		t.Errorf("problems = %q\nwant %q", invalid.Problems, want)
+	}
+}
@@ -0,0 +1,30 @@
+// Package dbtest connects tests to a real Postgres database.
+package dbtest
+
+import (
+	"database/sql"
+	"gin-app/db"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+)
+
+// Open connects to the migrated database named by TEST_DATABASE_URL,
+// skipping the test if it is unset.
+func Open(t testing.TB) *sql.DB {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	conn, err := sql.Open("postgres", url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if err := db.EnsureSchema(conn, true); err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
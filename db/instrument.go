@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	sqlc "gin-app/db/sqlc"
+	"gin-app/metrics"
+	"gin-app/tracing"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type instrumentedDB struct {
+	sqlc.DBTX
+}
+
+// Instrument wraps conn so every sqlc query gets a span and records its
+// latency under the query name from its "-- name:" header.
+func Instrument(conn sqlc.DBTX) sqlc.DBTX {
+	return instrumentedDB{conn}
+}
+
+func (d instrumentedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, done := start(ctx, query)
+	result, err := d.DBTX.ExecContext(ctx, query, args...)
+	done(err)
+	return result, err
+}
+
+func (d instrumentedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, done := start(ctx, query)
+	rows, err := d.DBTX.QueryContext(ctx, query, args...)
+	done(err)
+	return rows, err
+}
+
+func (d instrumentedDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, done := start(ctx, query)
+	row := d.DBTX.QueryRowContext(ctx, query, args...)
+	done(row.Err())
+	return row
+}
+
+func start(ctx context.Context, query string) (context.Context, func(error)) {
+	name := queryName(query)
+	begin := time.Now()
+	ctx, span := tracing.Tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation.name", name),
+		attribute.String("db.query.text", query),
+	))
+	return ctx, func(err error) {
+		metrics.DBQueryDuration.WithLabelValues(name).Observe(time.Since(begin).Seconds())
+		if err != nil && err != sql.ErrNoRows {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// queryName extracts "GetUserByID" from "-- name: GetUserByID :one\n...".
+func queryName(query string) string {
+	rest, ok := strings.CutPrefix(query, "-- name: ")
+	if !ok {
+		return "unnamed"
+	}
+	name, _, _ := strings.Cut(rest, " ")
+	return name
+}
@@ -0,0 +1,23 @@
+package db
+
+import "testing"
+
+func TestQueryName(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"-- name: GetUserByID :one\nSELECT * FROM users WHERE id = $1\n", "GetUserByID"},
+		{"-- name: PurgeDeletedUsers :execrows\nDELETE FROM users\n", "PurgeDeletedUsers"},
+		{"-- name: CountSessions", "CountSessions"},
+		{"SELECT version, dirty FROM schema_migrations LIMIT 1", "unnamed"},
+		{"  -- name: Indented :one\nSELECT 1", "unnamed"},
+		{"--name: NoSpace :one\nSELECT 1", "unnamed"},
+		{"", "unnamed"},
+	}
+	for _, tt := range tests {
+		if got := queryName(tt.query); got != tt.want {
+			t.Errorf("queryName(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
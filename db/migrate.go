@@ -0,0 +1,163 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"gin-app/db/migrations"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// NewMigrator returns a migrator for the embedded migrations on a dedicated
+// connection from db; closing it releases the connection but not the pool.
+// The postgres driver holds a session advisory lock while migrating, so
+// several instances booting at once apply each migration exactly once.
+func NewMigrator(db *sql.DB) (*migrate.Migrate, error) {
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, err
+	}
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	driver, err := postgres.WithConnection(context.Background(), conn, &postgres.Config{})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return migrate.NewWithInstance("iofs", source, "postgres", driver)
+}
+
+// LatestVersion returns the highest migration version embedded in the binary.
+func LatestVersion() (uint, error) {
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return 0, err
+	}
+	defer source.Close()
+	version, err := source.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := source.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
+
+// EnsureSchema applies pending migrations when autoMigrate is set, then fails
+// if the database is dirty or still behind the embedded migrations.
+func EnsureSchema(db *sql.DB, autoMigrate bool) error {
+	m, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	if autoMigrate {
+		if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("applying migrations: %w", err)
+		}
+	}
+	return CheckSchema(context.Background(), db)
+}
+
+// CheckSchema fails if the database is dirty or behind the embedded
+// migrations. It reads the version table directly, so it is cheap enough for
+// readiness probes.
+func CheckSchema(ctx context.Context, db *sql.DB) error {
+	latest, err := LatestVersion()
+	if err != nil {
+		return err
+	}
+	var version uint
+	var dirty bool
+	err = db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("database schema is dirty at version %d; fix it and run `migrate force`", version)
+	}
+	if version < latest {
+		return fmt.Errorf("database schema is at version %d but this build needs %d; run `migrate up` or set AUTO_MIGRATE=true", version, latest)
+	}
+	return nil
+}
+
+// RunMigrateCommand implements the `migrate` subcommand:
+//
+//	migrate up          apply all pending migrations
+//	migrate down [N]    roll back N migrations (default 1)
+//	migrate status      print the current and latest versions
+//	migrate force V     mark version V as applied and clean
+func RunMigrateCommand(db *sql.DB, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: migrate up|down [N]|status|force VERSION")
+	}
+	m, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch args[0] {
+	case "up":
+		err = m.Up()
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return errors.New("down takes a positive number of steps")
+			}
+		}
+		err = m.Steps(-steps)
+	case "force":
+		if len(args) < 2 {
+			return errors.New("usage: migrate force VERSION")
+		}
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		err = m.Force(version)
+	case "status":
+		latest, err := LatestVersion()
+		if err != nil {
+			return err
+		}
+		version, dirty, err := m.Version()
+		if errors.Is(err, migrate.ErrNilVersion) {
+			fmt.Fprintf(os.Stdout, "version: none\nlatest: %d\n", latest)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "version: %d\ndirty: %t\nlatest: %d\n", version, dirty, latest)
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command %q", args[0])
+	}
+
+	if errors.Is(err, migrate.ErrNoChange) {
+		slog.Info("no migrations to apply")
+		return nil
+	}
+	return err
+}
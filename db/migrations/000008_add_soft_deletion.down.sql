@@ -0,0 +1,10 @@
+DELETE FROM job_postings WHERE deleted_at IS NOT NULL;
+DELETE FROM companies WHERE deleted_at IS NOT NULL;
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS users_email_active_idx;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+ALTER TABLE job_postings DROP COLUMN deleted_at;
+ALTER TABLE companies DROP COLUMN deleted_at;
+ALTER TABLE users DROP COLUMN deleted_at;
\ No newline at end of file
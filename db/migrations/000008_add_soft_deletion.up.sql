@@ -0,0 +1,8 @@
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE companies ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE job_postings ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- A rejected or cancelled recruiter may sign up again with the same email,
+-- so uniqueness only applies to live accounts.
+ALTER TABLE users DROP CONSTRAINT users_email_key;
+CREATE UNIQUE INDEX users_email_active_idx ON users(email) WHERE deleted_at IS NULL;
\ No newline at end of file
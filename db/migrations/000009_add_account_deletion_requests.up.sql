@@ -0,0 +1,8 @@
+CREATE TABLE account_deletion_requests (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    scheduled_for TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX account_deletion_requests_due_idx ON account_deletion_requests(scheduled_for) WHERE completed_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE rate_limit_buckets (
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX rate_limit_buckets_updated_at_idx ON rate_limit_buckets(updated_at);
\ No newline at end of file
@@ -0,0 +1,8 @@
+ALTER TABLE users DROP CONSTRAINT users_role_fkey;
+UPDATE users SET role = 'pending' WHERE role = 'recruiter' AND status = 'pending';
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('admin', 'recruiter', 'applicant', 'pending'));
+ALTER TABLE users DROP COLUMN status;
+
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
\ No newline at end of file
@@ -0,0 +1,61 @@
+CREATE TABLE roles (
+    name TEXT PRIMARY KEY,
+    description TEXT NOT NULL
+);
+
+CREATE TABLE permissions (
+    name TEXT PRIMARY KEY,
+    description TEXT NOT NULL
+);
+
+CREATE TABLE role_permissions (
+    role TEXT NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission TEXT NOT NULL REFERENCES permissions(name) ON DELETE CASCADE,
+    PRIMARY KEY (role, permission)
+);
+
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Runs the platform'),
+    ('recruiter', 'Posts jobs for a company'),
+    ('applicant', 'Looks for a job');
+
+INSERT INTO permissions (name, description) VALUES
+    ('jobs:read', 'Browse job postings'),
+    ('jobs:write', 'Create and delete job postings'),
+    ('jobs:apply', 'Apply to job postings'),
+    ('profile:read', 'Read your own profile'),
+    ('profile:write', 'Edit your own skills profile'),
+    ('account:manage', 'Export or delete your own account'),
+    ('webhooks:manage', 'Manage your company''s webhooks'),
+    ('tokens:manage', 'Create and revoke personal access tokens'),
+    ('users:read', 'List users and deleted items'),
+    ('users:restore', 'Restore soft deleted users, companies and job postings'),
+    ('recruiters:approve', 'Approve or reject recruiter signups'),
+    ('audit:read', 'Read the audit log'),
+    ('metrics:read', 'Read internal metrics');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'jobs:read'),
+    ('admin', 'profile:read'),
+    ('admin', 'tokens:manage'),
+    ('admin', 'users:read'),
+    ('admin', 'users:restore'),
+    ('admin', 'recruiters:approve'),
+    ('admin', 'audit:read'),
+    ('admin', 'metrics:read'),
+    ('recruiter', 'jobs:read'),
+    ('recruiter', 'jobs:write'),
+    ('recruiter', 'profile:read'),
+    ('recruiter', 'webhooks:manage'),
+    ('recruiter', 'tokens:manage'),
+    ('applicant', 'jobs:read'),
+    ('applicant', 'jobs:apply'),
+    ('applicant', 'profile:read'),
+    ('applicant', 'profile:write'),
+    ('applicant', 'account:manage'),
+    ('applicant', 'tokens:manage');
+
+ALTER TABLE users ADD COLUMN status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'pending', 'suspended'));
+UPDATE users SET role = 'recruiter', status = 'pending' WHERE role = 'pending';
+ALTER TABLE users DROP CONSTRAINT users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_fkey FOREIGN KEY (role) REFERENCES roles(name);
\ No newline at end of file
@@ -0,0 +1,7 @@
+DELETE FROM permissions WHERE name = 'users:suspend';
+UPDATE users SET status = 'active' WHERE status = 'suspended';
+
+ALTER TABLE users
+    DROP COLUMN suspended_at,
+    DROP COLUMN suspended_until,
+    DROP COLUMN suspension_reason;
\ No newline at end of file
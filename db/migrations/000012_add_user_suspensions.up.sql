@@ -0,0 +1,7 @@
+ALTER TABLE users
+    ADD COLUMN suspended_at TIMESTAMPTZ,
+    ADD COLUMN suspended_until TIMESTAMPTZ,
+    ADD COLUMN suspension_reason TEXT;
+
+INSERT INTO permissions (name, description) VALUES ('users:suspend', 'Suspend and unsuspend users');
+INSERT INTO role_permissions (role, permission) VALUES ('admin', 'users:suspend');
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE applications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_posting_id UUID NOT NULL REFERENCES job_postings(id) ON DELETE CASCADE,
+    applicant_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    stage TEXT NOT NULL DEFAULT 'applied' CHECK (stage IN ('applied', 'screening', 'interview', 'offer', 'hired', 'rejected')),
+    source TEXT NOT NULL DEFAULT 'dashboard',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (job_posting_id, applicant_id)
+);
+
+CREATE INDEX applications_applicant_id_idx ON applications(applicant_id);
+
+CREATE INDEX users_created_at_idx ON users(created_at);
\ No newline at end of file
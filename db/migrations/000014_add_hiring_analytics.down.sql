@@ -0,0 +1,6 @@
+DELETE FROM permissions WHERE name = 'analytics:read';
+DROP INDEX IF EXISTS job_postings_company_id_idx;
+DROP MATERIALIZED VIEW IF EXISTS analytics_daily_signups;
+DROP TRIGGER IF EXISTS applications_record_stage ON applications;
+DROP FUNCTION IF EXISTS applications_record_stage();
+DROP TABLE IF EXISTS application_stage_events;
\ No newline at end of file
@@ -0,0 +1,41 @@
+CREATE TABLE application_stage_events (
+    id BIGSERIAL PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    stage TEXT NOT NULL,
+    entered_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX application_stage_events_application_idx ON application_stage_events(application_id, entered_at);
+
+CREATE FUNCTION applications_record_stage() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.stage IS DISTINCT FROM OLD.stage THEN
+        INSERT INTO application_stage_events (application_id, stage, entered_at)
+        VALUES (NEW.id, NEW.stage, now());
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER applications_record_stage
+AFTER INSERT OR UPDATE OF stage ON applications
+FOR EACH ROW EXECUTE FUNCTION applications_record_stage();
+
+INSERT INTO application_stage_events (application_id, stage, entered_at)
+SELECT id, 'applied', created_at FROM applications;
+
+INSERT INTO application_stage_events (application_id, stage, entered_at)
+SELECT id, stage, updated_at FROM applications WHERE stage <> 'applied';
+
+CREATE MATERIALIZED VIEW analytics_daily_signups AS
+SELECT date_trunc('day', created_at)::date AS day, role, count(*) AS signups
+FROM users
+WHERE created_at IS NOT NULL
+GROUP BY 1, 2;
+
+CREATE UNIQUE INDEX analytics_daily_signups_day_role_idx ON analytics_daily_signups(day, role);
+
+CREATE INDEX job_postings_company_id_idx ON job_postings(company_id);
+
+INSERT INTO permissions (name, description) VALUES ('analytics:read', 'View hiring analytics');
+INSERT INTO role_permissions (role, permission) VALUES ('admin', 'analytics:read');
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE job_posting_views (
+    id BIGSERIAL PRIMARY KEY,
+    job_posting_id UUID NOT NULL REFERENCES job_postings(id) ON DELETE CASCADE,
+    viewer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    source TEXT NOT NULL,
+    viewed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX job_posting_views_job_posting_idx ON job_posting_views(job_posting_id, viewed_at);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS job_posting_views_viewer_idx;
+DROP INDEX IF EXISTS job_posting_views_daily_idx;
+ALTER TABLE job_posting_views DROP COLUMN viewed_on;
\ No newline at end of file
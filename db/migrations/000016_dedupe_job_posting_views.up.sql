@@ -0,0 +1,14 @@
+DELETE FROM job_posting_views v
+USING job_posting_views d
+WHERE v.job_posting_id = d.job_posting_id
+  AND v.viewer_id = d.viewer_id
+  AND v.viewed_at::date = d.viewed_at::date
+  AND v.id > d.id;
+
+ALTER TABLE job_posting_views ADD COLUMN viewed_on DATE NOT NULL DEFAULT current_date;
+
+UPDATE job_posting_views SET viewed_on = viewed_at::date;
+
+CREATE UNIQUE INDEX job_posting_views_daily_idx ON job_posting_views(job_posting_id, viewer_id, viewed_on) WHERE viewer_id IS NOT NULL;
+
+CREATE INDEX job_posting_views_viewer_idx ON job_posting_views(viewer_id, viewed_at DESC);
\ No newline at end of file
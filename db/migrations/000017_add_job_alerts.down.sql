@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS notifications;
+DROP INDEX IF EXISTS job_postings_created_at_idx;
+DROP TABLE IF EXISTS saved_searches;
+DROP TABLE IF EXISTS saved_jobs;
\ No newline at end of file
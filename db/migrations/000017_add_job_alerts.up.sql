@@ -0,0 +1,37 @@
+CREATE TABLE saved_jobs (
+    applicant_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    job_posting_id UUID NOT NULL REFERENCES job_postings(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (applicant_id, job_posting_id)
+);
+
+CREATE TABLE saved_searches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    applicant_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    keywords TEXT NOT NULL DEFAULT '',
+    skills TEXT[] NOT NULL DEFAULT '{}',
+    min_salary INTEGER CHECK (min_salary > 0),
+    match_profile_skills BOOLEAN NOT NULL DEFAULT false,
+    frequency TEXT NOT NULL DEFAULT 'daily' CHECK (frequency IN ('instant', 'daily')),
+    last_alerted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX saved_searches_applicant_idx ON saved_searches(applicant_id);
+
+CREATE INDEX saved_searches_last_alerted_idx ON saved_searches(last_alerted_at);
+
+CREATE INDEX job_postings_created_at_idx ON job_postings(created_at);
+
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    link TEXT,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX notifications_user_idx ON notifications(user_id, created_at DESC);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS educations;
+DROP TABLE IF EXISTS work_experiences;
+DROP TABLE IF EXISTS applicant_profiles;
\ No newline at end of file
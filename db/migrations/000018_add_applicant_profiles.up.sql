@@ -0,0 +1,40 @@
+CREATE TABLE applicant_profiles (
+    applicant_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    headline TEXT NOT NULL DEFAULT '',
+    location TEXT NOT NULL DEFAULT '',
+    work_authorization TEXT NOT NULL DEFAULT '' CHECK (work_authorization IN ('', 'citizen', 'permanent_resident', 'work_visa', 'needs_sponsorship')),
+    years_experience INTEGER CHECK (years_experience BETWEEN 0 AND 70),
+    github_url TEXT NOT NULL DEFAULT '',
+    linkedin_url TEXT NOT NULL DEFAULT '',
+    portfolio_url TEXT NOT NULL DEFAULT '',
+    preferred_roles TEXT[] NOT NULL DEFAULT '{}',
+    preferred_salary INTEGER CHECK (preferred_salary > 0),
+    remote_preference TEXT NOT NULL DEFAULT '' CHECK (remote_preference IN ('', 'onsite', 'hybrid', 'remote')),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE work_experiences (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    applicant_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    company TEXT NOT NULL,
+    title TEXT NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE CHECK (end_date >= start_date),
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX work_experiences_applicant_idx ON work_experiences(applicant_id);
+
+CREATE TABLE educations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    applicant_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    school TEXT NOT NULL,
+    degree TEXT NOT NULL DEFAULT '',
+    field_of_study TEXT NOT NULL DEFAULT '',
+    start_year INTEGER NOT NULL,
+    end_year INTEGER CHECK (end_year >= start_year),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX educations_applicant_idx ON educations(applicant_id);
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE job_postings DROP COLUMN preferred_skills;
+DROP TABLE IF EXISTS job_posting_skills;
+DROP TABLE IF EXISTS applicant_skills;
+DROP VIEW IF EXISTS skill_lookup;
+DROP TABLE IF EXISTS skill_aliases;
+DROP TABLE IF EXISTS skills;
+DROP FUNCTION IF EXISTS skill_key(TEXT);
\ No newline at end of file
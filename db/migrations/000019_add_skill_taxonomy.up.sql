@@ -0,0 +1,155 @@
+CREATE FUNCTION skill_key(raw TEXT) RETURNS TEXT
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT lower(regexp_replace(btrim(raw), '\s+', ' ', 'g'))
+$$;
+
+CREATE TABLE skills (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    category TEXT NOT NULL DEFAULT 'other',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE skill_aliases (
+    alias TEXT PRIMARY KEY,
+    skill_id UUID NOT NULL REFERENCES skills(id) ON DELETE CASCADE
+);
+
+CREATE VIEW skill_lookup AS
+SELECT slug AS key, id AS skill_id FROM skills
+UNION ALL
+SELECT alias, skill_id FROM skill_aliases;
+
+CREATE TABLE applicant_skills (
+    applicant_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    skill_id UUID NOT NULL REFERENCES skills(id) ON DELETE CASCADE,
+    proficiency TEXT NOT NULL DEFAULT 'intermediate' CHECK (proficiency IN ('beginner', 'intermediate', 'advanced', 'expert')),
+    years INTEGER CHECK (years BETWEEN 0 AND 70),
+    PRIMARY KEY (applicant_id, skill_id)
+);
+
+CREATE INDEX applicant_skills_skill_idx ON applicant_skills(skill_id);
+
+CREATE TABLE job_posting_skills (
+    job_posting_id UUID NOT NULL REFERENCES job_postings(id) ON DELETE CASCADE,
+    skill_id UUID NOT NULL REFERENCES skills(id) ON DELETE CASCADE,
+    required BOOLEAN NOT NULL DEFAULT true,
+    PRIMARY KEY (job_posting_id, skill_id)
+);
+
+CREATE INDEX job_posting_skills_skill_idx ON job_posting_skills(skill_id);
+
+ALTER TABLE job_postings ADD COLUMN preferred_skills TEXT[] NOT NULL DEFAULT '{}';
+
+INSERT INTO skills (name, slug, category) VALUES
+    ('Go', 'go', 'language'),
+    ('Python', 'python', 'language'),
+    ('JavaScript', 'javascript', 'language'),
+    ('TypeScript', 'typescript', 'language'),
+    ('Java', 'java', 'language'),
+    ('Kotlin', 'kotlin', 'language'),
+    ('C#', 'c#', 'language'),
+    ('C++', 'c++', 'language'),
+    ('Ruby', 'ruby', 'language'),
+    ('PHP', 'php', 'language'),
+    ('Rust', 'rust', 'language'),
+    ('Swift', 'swift', 'language'),
+    ('SQL', 'sql', 'language'),
+    ('HTML', 'html', 'language'),
+    ('CSS', 'css', 'language'),
+    ('React', 'react', 'framework'),
+    ('Vue.js', 'vue.js', 'framework'),
+    ('Angular', 'angular', 'framework'),
+    ('Node.js', 'node.js', 'framework'),
+    ('Django', 'django', 'framework'),
+    ('Ruby on Rails', 'ruby on rails', 'framework'),
+    ('Spring', 'spring', 'framework'),
+    ('Gin', 'gin', 'framework'),
+    ('PostgreSQL', 'postgresql', 'database'),
+    ('MySQL', 'mysql', 'database'),
+    ('MongoDB', 'mongodb', 'database'),
+    ('Redis', 'redis', 'database'),
+    ('Docker', 'docker', 'devops'),
+    ('Kubernetes', 'kubernetes', 'devops'),
+    ('Terraform', 'terraform', 'devops'),
+    ('AWS', 'aws', 'cloud'),
+    ('Google Cloud', 'google cloud', 'cloud'),
+    ('Azure', 'azure', 'cloud'),
+    ('Git', 'git', 'tool'),
+    ('Linux', 'linux', 'tool'),
+    ('Machine Learning', 'machine learning', 'data'),
+    ('Data Analysis', 'data analysis', 'data');
+
+INSERT INTO skill_aliases (alias, skill_id)
+SELECT a.alias, s.id
+FROM (VALUES
+    ('golang', 'go'),
+    ('go lang', 'go'),
+    ('python3', 'python'),
+    ('js', 'javascript'),
+    ('ecmascript', 'javascript'),
+    ('ts', 'typescript'),
+    ('csharp', 'c#'),
+    ('c sharp', 'c#'),
+    ('cpp', 'c++'),
+    ('html5', 'html'),
+    ('css3', 'css'),
+    ('reactjs', 'react'),
+    ('react.js', 'react'),
+    ('vue', 'vue.js'),
+    ('vuejs', 'vue.js'),
+    ('angularjs', 'angular'),
+    ('node', 'node.js'),
+    ('nodejs', 'node.js'),
+    ('rails', 'ruby on rails'),
+    ('ror', 'ruby on rails'),
+    ('spring boot', 'spring'),
+    ('postgres', 'postgresql'),
+    ('psql', 'postgresql'),
+    ('mongo', 'mongodb'),
+    ('k8s', 'kubernetes'),
+    ('amazon web services', 'aws'),
+    ('gcp', 'google cloud'),
+    ('google cloud platform', 'google cloud'),
+    ('microsoft azure', 'azure'),
+    ('ml', 'machine learning')
+) AS a(alias, slug)
+JOIN skills s ON s.slug = a.slug;
+
+-- Every free-text value not already known becomes a skill of its own,
+-- named after its first spelling in alphabetical order.
+INSERT INTO skills (name, slug)
+SELECT DISTINCT ON (skill_key(v)) regexp_replace(btrim(v), '\s+', ' ', 'g'), skill_key(v)
+FROM (
+    SELECT unnest(skills) AS v FROM job_postings
+    UNION ALL
+    SELECT unnest(skills) FROM applicant_skill_sets
+) raw
+WHERE skill_key(v) <> ''
+  AND NOT EXISTS (SELECT 1 FROM skill_lookup l WHERE l.key = skill_key(v))
+ORDER BY skill_key(v), v;
+
+INSERT INTO applicant_skills (applicant_id, skill_id)
+SELECT DISTINCT a.applicant_id, l.skill_id
+FROM applicant_skill_sets a
+CROSS JOIN unnest(a.skills) AS v
+JOIN skill_lookup l ON l.key = skill_key(v);
+
+INSERT INTO job_posting_skills (job_posting_id, skill_id)
+SELECT DISTINCT jp.id, l.skill_id
+FROM job_postings jp
+CROSS JOIN unnest(jp.skills) AS v
+JOIN skill_lookup l ON l.key = skill_key(v);
+
+UPDATE applicant_skill_sets a SET skills = ARRAY(
+    SELECT s.name FROM applicant_skills x JOIN skills s ON s.id = x.skill_id
+    WHERE x.applicant_id = a.applicant_id
+    ORDER BY s.name
+);
+
+UPDATE job_postings jp SET skills = ARRAY(
+    SELECT s.name FROM job_posting_skills x JOIN skills s ON s.id = x.skill_id
+    WHERE x.job_posting_id = jp.id
+    ORDER BY s.name
+);
\ No newline at end of file
@@ -0,0 +1,27 @@
+CREATE TABLE screening_questions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_posting_id UUID NOT NULL REFERENCES job_postings(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    prompt TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('text', 'yes_no', 'single_choice', 'multi_choice', 'number')),
+    options TEXT[] NOT NULL DEFAULT '{}',
+    required BOOLEAN NOT NULL DEFAULT false,
+    knockout_answers TEXT[] NOT NULL DEFAULT '{}',
+    knockout_min INTEGER,
+    knockout_max INTEGER,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX screening_questions_job_posting_idx ON screening_questions(job_posting_id, position);
+
+-- Answers keep the prompt as it was asked, so removing or rewording a
+-- question does not change what an applicant appears to have answered.
+CREATE TABLE application_answers (
+    application_id UUID NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    question_id UUID REFERENCES screening_questions(id) ON DELETE SET NULL,
+    position INTEGER NOT NULL,
+    prompt TEXT NOT NULL,
+    answer TEXT[] NOT NULL DEFAULT '{}',
+    knocked_out BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY (application_id, position)
+);
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE applications
+    DROP CONSTRAINT applications_job_posting_id_fkey,
+    ADD CONSTRAINT applications_job_posting_id_fkey FOREIGN KEY (job_posting_id) REFERENCES job_postings(id) ON DELETE CASCADE,
+    DROP CONSTRAINT applications_applicant_id_fkey,
+    ADD CONSTRAINT applications_applicant_id_fkey FOREIGN KEY (applicant_id) REFERENCES users(id) ON DELETE CASCADE;
\ No newline at end of file
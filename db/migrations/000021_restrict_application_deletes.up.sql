@@ -0,0 +1,8 @@
+-- Applications, with their answers and stage history, are the hiring
+-- company's record. Deleting a posting or a user must not take them along,
+-- so the retention purge skips rows that applications still refer to.
+ALTER TABLE applications
+    DROP CONSTRAINT applications_job_posting_id_fkey,
+    ADD CONSTRAINT applications_job_posting_id_fkey FOREIGN KEY (job_posting_id) REFERENCES job_postings(id) ON DELETE RESTRICT,
+    DROP CONSTRAINT applications_applicant_id_fkey,
+    ADD CONSTRAINT applications_applicant_id_fkey FOREIGN KEY (applicant_id) REFERENCES users(id) ON DELETE RESTRICT;
\ No newline at end of file
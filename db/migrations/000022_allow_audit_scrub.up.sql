@@ -0,0 +1,17 @@
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    -- Anonymizing an account scrubs its personal data from the log, inside
+    -- a transaction that sets app.audit_scrub. Events are never deleted and
+    -- what they record never changes.
+    IF TG_OP = 'UPDATE' AND current_setting('app.audit_scrub', true) = 'on'
+        AND NEW.id = OLD.id
+        AND NEW.actor_id IS NOT DISTINCT FROM OLD.actor_id
+        AND NEW.action = OLD.action
+        AND NEW.target_type = OLD.target_type
+        AND NEW.target_id = OLD.target_id
+        AND NEW.created_at = OLD.created_at THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
\ No newline at end of file
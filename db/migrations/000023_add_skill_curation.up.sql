@@ -0,0 +1,9 @@
+ALTER TABLE skills ADD COLUMN curated BOOLEAN NOT NULL DEFAULT false;
+
+-- Seeded skills and those recruiters have posted with are curated. Names
+-- only applicants have typed stay out of autocomplete until a recruiter
+-- uses them.
+UPDATE skills s SET curated = true
+WHERE s.category <> 'other'
+   OR EXISTS (SELECT 1 FROM skill_aliases a WHERE a.skill_id = s.id)
+   OR EXISTS (SELECT 1 FROM job_posting_skills j WHERE j.skill_id = s.id);
\ No newline at end of file
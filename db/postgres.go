@@ -2,9 +2,8 @@ package db
 
 import (
 	"database/sql"
-	"fmt"
-	"log"
-	"os"
+	"gin-app/config"
+	"log/slog"
 
 	_ "github.com/lib/pq"
 )
@@ -13,18 +12,21 @@ import (
 
 // var Queries *db.Queries
 
-func NewDB() *sql.DB {
-	connStr := os.Getenv("DATABASE_URL")
-
-	db, err := sql.Open("postgres", connStr)
+func NewDB(cfg config.DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.URL)
 	if err != nil {
-		log.Fatal("Failed to connect to the database:", err)
+		return nil, err
 	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
 	if err = db.Ping(); err != nil {
-		log.Fatal("Cannot reach database:", err)
+		db.Close()
+		return nil, err
 	}
-	fmt.Println("Connected to database!")
+	slog.Info("connected to database")
 
-	return db
+	return db, nil
 }
@@ -0,0 +1,68 @@
+-- name: SaveJob :exec
+INSERT INTO saved_jobs (applicant_id, job_posting_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: UnsaveJob :exec
+DELETE FROM saved_jobs WHERE applicant_id = $1 AND job_posting_id = $2;
+
+-- name: ListSavedJobPostIDs :many
+SELECT job_posting_id FROM saved_jobs WHERE applicant_id = $1;
+
+-- name: ListSavedJobPosts :many
+SELECT jp.id, jp.company_name, jp.position, jp.salary, sj.created_at AS saved_at
+FROM saved_jobs sj
+JOIN job_postings jp ON jp.id = sj.job_posting_id
+WHERE sj.applicant_id = $1 AND jp.deleted_at IS NULL
+ORDER BY sj.created_at DESC;
+
+-- name: CreateSavedSearch :one
+INSERT INTO saved_searches (applicant_id, keywords, skills, min_salary, match_profile_skills, frequency)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListSavedSearches :many
+SELECT * FROM saved_searches WHERE applicant_id = $1 ORDER BY created_at DESC;
+
+-- name: DeleteSavedSearch :execrows
+DELETE FROM saved_searches WHERE id = $1 AND applicant_id = $2;
+
+-- name: ClaimDueSavedSearches :many
+UPDATE saved_searches s SET last_alerted_at = now()
+FROM (
+    SELECT ss.id, ss.last_alerted_at FROM saved_searches ss
+    JOIN users u ON u.id = ss.applicant_id
+    WHERE u.deleted_at IS NULL
+      AND NOT (u.status = 'suspended' AND (u.suspended_until IS NULL OR u.suspended_until > now()))
+      AND (ss.frequency = 'instant' OR ss.last_alerted_at <= now() - interval '1 day')
+      AND EXISTS (SELECT 1 FROM job_postings jp WHERE jp.created_at > ss.last_alerted_at AND jp.deleted_at IS NULL)
+    ORDER BY ss.last_alerted_at
+    LIMIT $1
+    FOR UPDATE OF ss SKIP LOCKED
+) due
+WHERE s.id = due.id
+RETURNING s.id, s.applicant_id, s.keywords, s.skills, s.min_salary, s.match_profile_skills, s.frequency,
+    due.last_alerted_at AS since, s.last_alerted_at AS until;
+
+-- name: ListJobPostsPublishedBetween :many
+SELECT * FROM job_postings jp
+WHERE jp.created_at > sqlc.arg(since)::timestamptz AND jp.created_at <= sqlc.arg(until)::timestamptz
+  AND jp.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM companies c WHERE c.id = jp.company_id AND c.deleted_at IS NOT NULL)
+  AND NOT EXISTS (
+    SELECT 1 FROM users u
+    WHERE u.id = jp.recruiter_id AND u.status = 'suspended'
+      AND (u.suspended_until IS NULL OR u.suspended_until > now())
+  )
+  AND NOT EXISTS (SELECT 1 FROM applications a WHERE a.job_posting_id = jp.id AND a.applicant_id = sqlc.arg(applicant_id))
+ORDER BY jp.created_at;
+
+-- name: CreateNotification :exec
+INSERT INTO notifications (user_id, kind, subject, body, link)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListNotifications :many
+SELECT * FROM notifications WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2;
+
+-- name: MarkNotificationsRead :exec
+UPDATE notifications SET read_at = now() WHERE user_id = $1 AND read_at IS NULL;
\ No newline at end of file
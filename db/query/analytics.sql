@@ -0,0 +1,92 @@
+-- name: RefreshDailySignups :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY analytics_daily_signups;
+
+-- name: ListDailySignups :many
+SELECT day, role, signups FROM analytics_daily_signups
+WHERE day >= sqlc.arg(since)::date
+ORDER BY day, role;
+
+-- name: ListActivePostingsPerCompany :many
+SELECT c.id, c.name, count(jp.id) AS postings
+FROM companies c
+JOIN job_postings jp ON jp.company_id = c.id AND jp.deleted_at IS NULL
+WHERE c.deleted_at IS NULL
+GROUP BY c.id, c.name
+ORDER BY postings DESC, c.name
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListApplicationsPerPosting :many
+SELECT jp.id, jp.company_name, jp.position, count(a.id) AS applications
+FROM job_postings jp
+LEFT JOIN applications a ON a.job_posting_id = jp.id
+WHERE jp.deleted_at IS NULL
+GROUP BY jp.id, jp.company_name, jp.position
+ORDER BY applications DESC, jp.position
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetHiringFunnel :many
+SELECT stage, count(DISTINCT application_id) AS applications
+FROM application_stage_events
+GROUP BY stage;
+
+-- name: GetTimeToHire :one
+SELECT count(*) AS hires,
+  COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY extract(epoch FROM e.entered_at - a.created_at)), 0)::float8 AS median_seconds
+FROM application_stage_events e
+JOIN applications a ON a.id = e.application_id
+WHERE e.stage = 'hired';
+
+-- name: ListTimeInStage :many
+SELECT stage, count(*) AS transitions,
+  percentile_cont(0.5) WITHIN GROUP (ORDER BY extract(epoch FROM left_at - entered_at))::float8 AS median_seconds
+FROM (
+  SELECT stage, entered_at, lead(entered_at) OVER (PARTITION BY application_id ORDER BY entered_at, id) AS left_at
+  FROM application_stage_events
+) stays
+WHERE left_at IS NOT NULL
+GROUP BY stage;
+
+-- name: GetPendingRecruiterQueue :one
+SELECT count(*) AS pending,
+  COALESCE(extract(epoch FROM now() - min(created_at)), 0)::float8 AS oldest_seconds,
+  COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY extract(epoch FROM now() - created_at)), 0)::float8 AS median_seconds
+FROM users
+WHERE role = 'recruiter' AND status = 'pending' AND deleted_at IS NULL;
+
+-- name: RecordJobPostingView :exec
+INSERT INTO job_posting_views (job_posting_id, viewer_id, source)
+VALUES ($1, $2, $3)
+ON CONFLICT (job_posting_id, viewer_id, viewed_on) WHERE viewer_id IS NOT NULL
+DO UPDATE SET viewed_at = now();
+
+-- name: ListRecentlyViewedJobPosts :many
+SELECT jp.id, jp.company_name, jp.position, max(v.viewed_at)::timestamptz AS last_viewed_at
+FROM job_posting_views v
+JOIN job_postings jp ON jp.id = v.job_posting_id
+WHERE v.viewer_id = $1 AND jp.deleted_at IS NULL
+GROUP BY jp.id, jp.company_name, jp.position
+ORDER BY last_viewed_at DESC
+LIMIT $2;
+
+-- name: ListCompanyPostingStats :many
+SELECT jp.id, jp.position, jp.skills, jp.created_at,
+  (SELECT count(*) FROM job_posting_views v WHERE v.job_posting_id = jp.id) AS views,
+  (SELECT count(DISTINCT v.viewer_id) FROM job_posting_views v WHERE v.job_posting_id = jp.id) AS viewers,
+  (SELECT count(*) FROM applications a WHERE a.job_posting_id = jp.id) AS applications
+FROM job_postings jp
+WHERE jp.company_id = $1 AND jp.deleted_at IS NULL
+ORDER BY jp.created_at DESC;
+
+-- name: ListCompanyApplicationBreakdown :many
+SELECT a.job_posting_id, a.stage, a.source, count(*) AS applications
+FROM applications a
+JOIN job_postings jp ON jp.id = a.job_posting_id
+WHERE jp.company_id = $1 AND jp.deleted_at IS NULL
+GROUP BY a.job_posting_id, a.stage, a.source;
+
+-- name: ListCompanyApplicantSkills :many
+SELECT a.job_posting_id, COALESCE(s.skills, '{}')::text[] AS skills
+FROM applications a
+JOIN job_postings jp ON jp.id = a.job_posting_id
+LEFT JOIN applicant_skill_sets s ON s.applicant_id = a.applicant_id
+WHERE jp.company_id = $1 AND jp.deleted_at IS NULL;
\ No newline at end of file
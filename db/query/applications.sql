@@ -0,0 +1,39 @@
+-- name: CreateApplication :one
+INSERT INTO applications (job_posting_id, applicant_id, source)
+VALUES ($1, $2, $3)
+ON CONFLICT (job_posting_id, applicant_id) DO NOTHING
+RETURNING *;
+
+-- name: ListAppliedJobPostIDs :many
+SELECT job_posting_id FROM applications WHERE applicant_id = $1;
+
+-- name: ListApplicationsByApplicant :many
+SELECT a.id, a.job_posting_id, a.stage, a.source, a.created_at, jp.company_name, jp.position
+FROM applications a
+JOIN job_postings jp ON jp.id = a.job_posting_id
+WHERE a.applicant_id = $1
+ORDER BY a.created_at DESC;
+
+-- name: ListJobPostsByRecruiter :many
+SELECT jp.id, jp.company_name, jp.position, jp.created_at, jp.deleted_at,
+  (SELECT count(*) FROM applications a WHERE a.job_posting_id = jp.id) AS application_count
+FROM job_postings jp
+WHERE jp.recruiter_id = $1
+ORDER BY jp.created_at DESC;
+
+-- name: ListJobPostApplications :many
+SELECT a.id, a.applicant_id, a.stage, a.source, a.created_at, u.name, u.email
+FROM applications a
+JOIN users u ON u.id = a.applicant_id
+WHERE a.job_posting_id = $1
+ORDER BY a.created_at DESC;
+
+-- name: RejectApplication :exec
+UPDATE applications SET stage = 'rejected', updated_at = now() WHERE id = $1;
+
+-- name: GetJobPostApplicationForUpdate :one
+SELECT * FROM applications WHERE id = $1 AND job_posting_id = $2 FOR UPDATE;
+
+-- name: UpdateApplicationStage :one
+UPDATE applications SET stage = $2, updated_at = now() WHERE id = $1
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (actor_id, actor_email, action, target_type, target_id, before, after, ip, user_agent)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: SearchAuditEvents :many
+SELECT * FROM audit_events
+WHERE (sqlc.narg(actor)::text IS NULL OR actor_email ILIKE '%' || sqlc.narg(actor) || '%')
+  AND (sqlc.narg(action)::text IS NULL OR action = sqlc.narg(action))
+  AND (sqlc.narg(since)::timestamptz IS NULL OR created_at >= sqlc.narg(since))
+  AND (sqlc.narg(until)::timestamptz IS NULL OR created_at < sqlc.narg(until))
+ORDER BY created_at DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListAuditActions :many
+SELECT DISTINCT action FROM audit_events ORDER BY action;
@@ -0,0 +1,2 @@
+-- name: ListRolePermissions :many
+SELECT role, permission FROM role_permissions ORDER BY role, permission;
\ No newline at end of file
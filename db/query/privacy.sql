@@ -0,0 +1,75 @@
+-- name: GetApplicantSkillSet :one
+SELECT * FROM applicant_skill_sets WHERE applicant_id = $1;
+
+-- name: ListUserSessions :many
+SELECT created_at FROM sessions WHERE user_id = $1 ORDER BY created_at;
+
+-- name: ListAuditEventsByActor :many
+SELECT * FROM audit_events WHERE actor_id = $1 ORDER BY id;
+
+-- name: RequestAccountDeletion :one
+INSERT INTO account_deletion_requests (user_id, scheduled_for)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET requested_at = now(), scheduled_for = $2, completed_at = NULL
+RETURNING *;
+
+-- name: GetPendingAccountDeletion :one
+SELECT * FROM account_deletion_requests WHERE user_id = $1 AND completed_at IS NULL;
+
+-- name: CancelAccountDeletion :exec
+DELETE FROM account_deletion_requests WHERE user_id = $1 AND completed_at IS NULL;
+
+-- name: ListDueAccountDeletions :many
+SELECT user_id FROM account_deletion_requests
+WHERE completed_at IS NULL AND scheduled_for <= now()
+ORDER BY scheduled_for
+LIMIT $1;
+
+-- name: CompleteAccountDeletion :exec
+UPDATE account_deletion_requests SET completed_at = now() WHERE user_id = $1;
+
+-- name: AnonymizeUser :exec
+UPDATE users
+SET name = 'Deleted user', email = 'deleted-' || id || '@invalid', picture = NULL, suspension_reason = NULL, deleted_at = COALESCE(deleted_at, now())
+WHERE id = $1;
+
+-- name: DeleteApplicantSkillSet :exec
+DELETE FROM applicant_skill_sets WHERE applicant_id = $1;
+
+-- name: DeleteUserTokens :exec
+DELETE FROM personal_access_tokens WHERE user_id = $1;
+
+-- name: DeleteSavedSearches :exec
+DELETE FROM saved_searches WHERE applicant_id = $1;
+
+-- name: DeleteSavedJobs :exec
+DELETE FROM saved_jobs WHERE applicant_id = $1;
+
+-- name: DeleteNotifications :exec
+DELETE FROM notifications WHERE user_id = $1;
+
+-- name: DeleteApplicantProfile :exec
+DELETE FROM applicant_profiles WHERE applicant_id = $1;
+
+-- name: DeleteWorkExperiences :exec
+DELETE FROM work_experiences WHERE applicant_id = $1;
+
+-- name: DeleteEducations :exec
+DELETE FROM educations WHERE applicant_id = $1;
+
+-- name: DeleteApplicantAnswers :exec
+DELETE FROM application_answers
+WHERE application_id IN (SELECT id FROM applications WHERE applicant_id = $1);
+
+-- name: AllowAuditScrub :exec
+SELECT set_config('app.audit_scrub', 'on', true);
+
+-- name: ScrubAuditEventsByActor :exec
+UPDATE audit_events SET actor_email = NULL, ip = NULL, user_agent = NULL
+WHERE actor_id = $1;
+
+-- name: ScrubAuditEventsAboutUser :exec
+UPDATE audit_events
+SET before = before - ARRAY['name', 'email', 'suspension_reason', 'skills'],
+  after = after - ARRAY['name', 'email', 'suspension_reason', 'skills']
+WHERE target_type = 'user' AND target_id = $1;
\ No newline at end of file
@@ -0,0 +1,46 @@
+-- name: GetApplicantProfile :one
+SELECT * FROM applicant_profiles WHERE applicant_id = $1;
+
+-- name: UpsertApplicantProfile :one
+INSERT INTO applicant_profiles (
+    applicant_id, headline, location, work_authorization, years_experience,
+    github_url, linkedin_url, portfolio_url, preferred_roles, preferred_salary, remote_preference
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (applicant_id) DO UPDATE SET
+    headline = EXCLUDED.headline,
+    location = EXCLUDED.location,
+    work_authorization = EXCLUDED.work_authorization,
+    years_experience = EXCLUDED.years_experience,
+    github_url = EXCLUDED.github_url,
+    linkedin_url = EXCLUDED.linkedin_url,
+    portfolio_url = EXCLUDED.portfolio_url,
+    preferred_roles = EXCLUDED.preferred_roles,
+    preferred_salary = EXCLUDED.preferred_salary,
+    remote_preference = EXCLUDED.remote_preference,
+    updated_at = now()
+RETURNING *;
+
+-- name: ListWorkExperiences :many
+SELECT * FROM work_experiences WHERE applicant_id = $1
+ORDER BY end_date DESC NULLS FIRST, start_date DESC;
+
+-- name: CreateWorkExperience :one
+INSERT INTO work_experiences (applicant_id, company, title, start_date, end_date, description)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: DeleteWorkExperience :execrows
+DELETE FROM work_experiences WHERE id = $1 AND applicant_id = $2;
+
+-- name: ListEducations :many
+SELECT * FROM educations WHERE applicant_id = $1
+ORDER BY end_year DESC NULLS FIRST, start_year DESC;
+
+-- name: CreateEducation :one
+INSERT INTO educations (applicant_id, school, degree, field_of_study, start_year, end_year)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: DeleteEducation :execrows
+DELETE FROM educations WHERE id = $1 AND applicant_id = $2;
\ No newline at end of file
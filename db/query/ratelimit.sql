@@ -0,0 +1,22 @@
+-- name: TakeRateLimitToken :one
+INSERT INTO rate_limit_buckets AS b (key, tokens, updated_at)
+VALUES (sqlc.arg(key), sqlc.arg(burst)::double precision - 1, now())
+ON CONFLICT (key) DO UPDATE
+SET tokens = LEAST(sqlc.arg(burst)::double precision, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * sqlc.arg(per_second)::double precision) - 1,
+    updated_at = now()
+WHERE LEAST(sqlc.arg(burst)::double precision, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * sqlc.arg(per_second)::double precision) >= 1
+RETURNING tokens;
+
+-- name: GetRateLimitWait :one
+SELECT ((1 - LEAST(sqlc.arg(burst)::double precision, tokens + EXTRACT(EPOCH FROM now() - updated_at) * sqlc.arg(per_second)::double precision)) / sqlc.arg(per_second)::double precision)::double precision AS wait_seconds
+FROM rate_limit_buckets
+WHERE key = sqlc.arg(key);
+
+-- name: DeleteIdleRateLimitBuckets :execrows
+DELETE FROM rate_limit_buckets
+WHERE updated_at < sqlc.arg(cutoff);
+
+-- name: RefundRateLimitToken :exec
+UPDATE rate_limit_buckets
+SET tokens = LEAST(sqlc.arg(burst)::double precision, tokens + 1)
+WHERE key = sqlc.arg(key);
\ No newline at end of file
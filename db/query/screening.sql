@@ -0,0 +1,37 @@
+-- name: ListScreeningQuestions :many
+SELECT * FROM screening_questions WHERE job_posting_id = $1
+ORDER BY position, created_at;
+
+-- name: CreateScreeningQuestion :one
+INSERT INTO screening_questions (
+    job_posting_id, position, prompt, kind, options, required, knockout_answers, knockout_min, knockout_max
+)
+VALUES (
+    $1, (SELECT coalesce(max(position), 0) + 1 FROM screening_questions WHERE job_posting_id = $1),
+    $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: DeleteScreeningQuestion :execrows
+DELETE FROM screening_questions WHERE id = $1 AND job_posting_id = $2;
+
+-- name: ListScreenedJobPostIDs :many
+SELECT DISTINCT job_posting_id FROM screening_questions;
+
+-- name: AddApplicationAnswer :exec
+INSERT INTO application_answers (application_id, question_id, position, prompt, answer, knocked_out)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListJobPostAnswers :many
+SELECT ans.application_id, ans.prompt, ans.answer, ans.knocked_out
+FROM application_answers ans
+JOIN applications a ON a.id = ans.application_id
+WHERE a.job_posting_id = $1
+ORDER BY ans.application_id, ans.position;
+
+-- name: ListApplicantAnswers :many
+SELECT ans.application_id, ans.prompt, ans.answer
+FROM application_answers ans
+JOIN applications a ON a.id = ans.application_id
+WHERE a.applicant_id = $1
+ORDER BY ans.application_id, ans.position;
\ No newline at end of file
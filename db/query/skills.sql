@@ -0,0 +1,40 @@
+-- name: GetSkillByKey :one
+SELECT s.* FROM skill_lookup l
+JOIN skills s ON s.id = l.skill_id
+WHERE l.key = skill_key(sqlc.arg(name)::text);
+
+-- name: CreateSkill :one
+INSERT INTO skills (name, slug, curated)
+VALUES ($1, skill_key($1), $2)
+ON CONFLICT (slug) DO UPDATE SET curated = skills.curated OR EXCLUDED.curated
+RETURNING *;
+
+-- name: SearchSkills :many
+SELECT s.name, s.category FROM skills s
+WHERE s.curated
+  AND (s.slug LIKE sqlc.arg(prefix)::text || '%'
+    OR EXISTS (SELECT 1 FROM skill_aliases a WHERE a.skill_id = s.id AND a.alias LIKE sqlc.arg(prefix) || '%'))
+ORDER BY (SELECT count(*) FROM job_posting_skills j WHERE j.skill_id = s.id) DESC, s.name
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListApplicantSkills :many
+SELECT a.skill_id, s.name, s.category, a.proficiency, a.years
+FROM applicant_skills a
+JOIN skills s ON s.id = a.skill_id
+WHERE a.applicant_id = $1
+ORDER BY s.name;
+
+-- name: DeleteApplicantSkills :exec
+DELETE FROM applicant_skills WHERE applicant_id = $1;
+
+-- name: AddApplicantSkill :exec
+INSERT INTO applicant_skills (applicant_id, skill_id, proficiency, years)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (applicant_id, skill_id) DO UPDATE SET
+    proficiency = EXCLUDED.proficiency,
+    years = EXCLUDED.years;
+
+-- name: AddJobPostingSkill :exec
+INSERT INTO job_posting_skills (job_posting_id, skill_id, required)
+VALUES ($1, $2, $3)
+ON CONFLICT (job_posting_id, skill_id) DO UPDATE SET required = job_posting_skills.required OR EXCLUDED.required;
\ No newline at end of file
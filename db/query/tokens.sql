@@ -0,0 +1,22 @@
+-- name: CreatePersonalAccessToken :one
+INSERT INTO personal_access_tokens (user_id, name, token_hash, prefix, scopes, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListPersonalAccessTokens :many
+SELECT id, name, prefix, scopes, expires_at, last_used_at, created_at
+FROM personal_access_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetPersonalAccessTokenByHash :one
+SELECT t.id, t.user_id, t.scopes, t.expires_at, u.name, u.email, u.role, u.status, u.suspended_until
+FROM personal_access_tokens t
+JOIN users u ON u.id = t.user_id
+WHERE t.token_hash = $1 AND u.deleted_at IS NULL;
+
+-- name: TouchPersonalAccessToken :exec
+UPDATE personal_access_tokens SET last_used_at = now() WHERE id = $1;
+
+-- name: DeletePersonalAccessToken :exec
+DELETE FROM personal_access_tokens WHERE id = $1 AND user_id = $2;
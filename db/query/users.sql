@@ -1,10 +1,10 @@
 -- name: CreateUser :one
-INSERT INTO users (name, email, picture, role)
-VALUES ($1, $2, $3, $4)
+INSERT INTO users (name, email, picture, role, status)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: GetUserByEmail :one
-SELECT * FROM users WHERE email = $1;
+SELECT * FROM users WHERE email = $1 AND deleted_at IS NULL;
 
 -- name: CreateOrUpdateSession :one
 INSERT INTO sessions (user_id, token)
@@ -21,17 +21,41 @@ SELECT * FROM sessions WHERE token = $1;
 -- name: DeleteSession :exec
 DELETE FROM sessions WHERE user_id = $1 AND token = $2;
 
--- name: GetAllUsers :many
-SELECT id, name, email, picture, role FROM users WHERE role IN ('applicant', 'recruiter');
+-- name: SearchUsers :many
+SELECT id, name, email, role, status, created_at, suspended_until FROM users
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(search)::text IS NULL OR name ILIKE '%' || sqlc.narg(search) || '%' OR email ILIKE '%' || sqlc.narg(search) || '%')
+  AND (sqlc.narg(role)::text IS NULL OR role = sqlc.narg(role))
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(joined_from)::timestamptz IS NULL OR created_at >= sqlc.narg(joined_from))
+  AND (sqlc.narg(joined_to)::timestamptz IS NULL OR created_at < sqlc.narg(joined_to))
+ORDER BY
+  CASE WHEN sqlc.arg(sort)::text = 'name' AND NOT sqlc.arg(descending)::bool THEN name END ASC,
+  CASE WHEN sqlc.arg(sort) = 'name' AND sqlc.arg(descending) THEN name END DESC,
+  CASE WHEN sqlc.arg(sort) = 'email' AND NOT sqlc.arg(descending) THEN email END ASC,
+  CASE WHEN sqlc.arg(sort) = 'email' AND sqlc.arg(descending) THEN email END DESC,
+  CASE WHEN sqlc.arg(sort) = 'joined' AND NOT sqlc.arg(descending) THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort) = 'joined' AND sqlc.arg(descending) THEN created_at END DESC,
+  id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountUsers :one
+SELECT count(*) FROM users
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(search)::text IS NULL OR name ILIKE '%' || sqlc.narg(search) || '%' OR email ILIKE '%' || sqlc.narg(search) || '%')
+  AND (sqlc.narg(role)::text IS NULL OR role = sqlc.narg(role))
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(joined_from)::timestamptz IS NULL OR created_at >= sqlc.narg(joined_from))
+  AND (sqlc.narg(joined_to)::timestamptz IS NULL OR created_at < sqlc.narg(joined_to));
 
 -- name: GetPendingRecruiters :many
-SELECT id, name, email, picture FROM users WHERE role = 'pending';
+SELECT id, name, email, picture FROM users WHERE role = 'recruiter' AND status = 'pending' AND deleted_at IS NULL;
 
 -- name: ApproveRecruiter :exec
-UPDATE users SET role = 'recruiter' WHERE id = $1;
+UPDATE users SET status = 'active' WHERE id = $1;
 
 -- name: RejectRecruiter :exec
-DELETE FROM users WHERE id = $1;
+UPDATE users SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: CreateCompany :one
 INSERT INTO companies (id, recruiter_id, name, description, logo)
@@ -39,21 +63,117 @@ VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: RejectCompany :exec
-DELETE FROM companies WHERE recruiter_id = $1;
+UPDATE companies SET deleted_at = now() WHERE recruiter_id = $1 AND deleted_at IS NULL;
 
 -- name: GetCompanyByRecruiterID :one
-SELECT * FROM companies WHERE recruiter_id = $1;
+SELECT * FROM companies WHERE recruiter_id = $1 AND deleted_at IS NULL;
+
+-- name: GetCompanyByID :one
+SELECT * FROM companies WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: GetAllJobPosts :many
-SELECT * FROM job_postings;
+SELECT * FROM job_postings jp
+WHERE jp.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM companies c WHERE c.id = jp.company_id AND c.deleted_at IS NOT NULL)
+  AND NOT EXISTS (
+    SELECT 1 FROM users u
+    WHERE u.id = jp.recruiter_id AND u.status = 'suspended'
+      AND (u.suspended_until IS NULL OR u.suspended_until > now())
+  );
 
 -- name: CreateJobPost :exec
-INSERT INTO job_postings (id, recruiter_id, company_id, company_name, position, skills, description, salary)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+INSERT INTO job_postings (id, recruiter_id, company_id, company_name, position, skills, description, salary, preferred_skills)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
 
 -- name: DeleteJobPost :exec
-DELETE FROM job_postings WHERE id = $1;
+UPDATE job_postings SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: UpdateApplicantSkills :exec
 INSERT INTO applicant_skill_sets (applicant_id, skills)
-VALUES ($1, $2) ON CONFLICT (applicant_id) DO UPDATE SET skills = $2;
\ No newline at end of file
+VALUES ($1, $2) ON CONFLICT (applicant_id) DO UPDATE SET skills = $2;
+
+-- name: GetUserByID :one
+SELECT * FROM users WHERE id = $1;
+
+-- name: GetJobPostByID :one
+SELECT * FROM job_postings WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: DeleteUserSessions :exec
+DELETE FROM sessions WHERE user_id = $1;
+
+-- name: ListDeletedUsers :many
+SELECT id, name, email, role, deleted_at FROM users
+WHERE deleted_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM account_deletion_requests r WHERE r.user_id = users.id AND r.completed_at IS NOT NULL)
+ORDER BY deleted_at DESC;
+
+-- name: ListDeletedCompanies :many
+SELECT id, recruiter_id, name, deleted_at FROM companies
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC;
+
+-- name: ListDeletedJobPosts :many
+SELECT id, company_name, position, deleted_at FROM job_postings
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC;
+
+-- name: RestoreUser :exec
+UPDATE users SET deleted_at = NULL WHERE id = $1;
+
+-- name: RestoreCompany :exec
+UPDATE companies SET deleted_at = NULL WHERE id = $1;
+
+-- name: RestoreJobPost :exec
+UPDATE job_postings SET deleted_at = NULL WHERE id = $1;
+
+-- name: PurgeDeletedJobPosts :execrows
+DELETE FROM job_postings WHERE deleted_at < $1
+  AND NOT EXISTS (SELECT 1 FROM applications a WHERE a.job_posting_id = job_postings.id);
+
+-- name: PurgeDeletedCompanies :execrows
+DELETE FROM companies WHERE deleted_at < $1
+  AND NOT EXISTS (SELECT 1 FROM job_postings p JOIN applications a ON a.job_posting_id = p.id WHERE p.company_id = companies.id);
+
+-- name: PurgeDeletedUsers :execrows
+DELETE FROM users WHERE deleted_at < $1
+  AND NOT EXISTS (SELECT 1 FROM account_deletion_requests r WHERE r.user_id = users.id AND r.completed_at IS NOT NULL)
+  AND NOT EXISTS (SELECT 1 FROM applications a WHERE a.applicant_id = users.id)
+  AND NOT EXISTS (SELECT 1 FROM job_postings p JOIN applications a ON a.job_posting_id = p.id WHERE p.recruiter_id = users.id);
+
+-- name: LockUser :one
+SELECT * FROM users WHERE id = $1 FOR UPDATE;
+
+-- name: CountSessions :one
+SELECT count(*) FROM sessions;
+
+-- name: SuspendUser :exec
+UPDATE users
+SET status = 'suspended', suspended_at = now(), suspended_until = $2, suspension_reason = $3
+WHERE id = $1;
+
+-- name: UnsuspendUser :exec
+UPDATE users
+SET status = 'active', suspended_at = NULL, suspended_until = NULL, suspension_reason = NULL
+WHERE id = $1 AND status = 'suspended';
+
+-- name: ListSuspendedUsers :many
+SELECT id, name, email, role, suspended_at, suspended_until, suspension_reason FROM users
+WHERE status = 'suspended' AND deleted_at IS NULL
+ORDER BY suspended_at DESC;
+
+-- name: GetSessionUser :one
+SELECT u.id, u.role, u.status
+FROM sessions s
+JOIN users u ON u.id = s.user_id
+WHERE s.token = $1 AND u.deleted_at IS NULL;
+
+-- name: GetVisibleJobPost :one
+SELECT * FROM job_postings jp
+WHERE jp.id = $1
+  AND jp.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM companies c WHERE c.id = jp.company_id AND c.deleted_at IS NOT NULL)
+  AND NOT EXISTS (
+    SELECT 1 FROM users u
+    WHERE u.id = jp.recruiter_id AND u.status = 'suspended'
+      AND (u.suspended_until IS NULL OR u.suspended_until > now())
+  );
\ No newline at end of file
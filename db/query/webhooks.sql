@@ -0,0 +1,72 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (company_id, url, secret, event_types)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions WHERE company_id = $1 ORDER BY created_at DESC;
+
+-- name: GetWebhookSubscription :one
+SELECT * FROM webhook_subscriptions WHERE id = $1 AND company_id = $2;
+
+-- name: GetWebhookSubscriptionByID :one
+SELECT * FROM webhook_subscriptions WHERE id = $1;
+
+-- name: DeleteWebhookSubscription :exec
+DELETE FROM webhook_subscriptions WHERE id = $1 AND company_id = $2;
+
+-- name: EnableWebhookSubscription :exec
+UPDATE webhook_subscriptions
+SET active = true, consecutive_failures = 0, disabled_at = NULL
+WHERE id = $1 AND company_id = $2;
+
+-- name: DisableWebhookSubscription :exec
+UPDATE webhook_subscriptions SET active = false, disabled_at = now() WHERE id = $1;
+
+-- name: ListActiveWebhookSubscriptionsForEvent :many
+SELECT * FROM webhook_subscriptions
+WHERE company_id = $1 AND active AND sqlc.arg(event_type)::text = ANY(event_types);
+
+-- name: RecordWebhookSuccess :exec
+UPDATE webhook_subscriptions SET consecutive_failures = 0 WHERE id = $1;
+
+-- name: RecordWebhookFailure :one
+UPDATE webhook_subscriptions SET consecutive_failures = consecutive_failures + 1 WHERE id = $1
+RETURNING consecutive_failures;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (subscription_id, event_type, payload)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries SET next_attempt_at = now() + interval '5 minutes'
+WHERE id IN (
+    SELECT d.id FROM webhook_deliveries d
+    JOIN webhook_subscriptions s ON s.id = d.subscription_id
+    WHERE d.status = 'pending' AND d.next_attempt_at <= now() AND s.active
+    ORDER BY d.next_attempt_at
+    LIMIT $1
+    FOR UPDATE OF d SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkWebhookDeliverySucceeded :exec
+UPDATE webhook_deliveries
+SET status = 'succeeded', attempts = attempts + 1, response_code = $2, response_body = $3,
+    last_error = NULL, delivered_at = now()
+WHERE id = $1;
+
+-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+SET status = $2, attempts = attempts + 1, response_code = $3, response_body = $4,
+    last_error = $5, next_attempt_at = $6
+WHERE id = $1;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries WHERE subscription_id = $1 ORDER BY created_at DESC LIMIT 50;
+
+-- name: RedeliverWebhookDelivery :exec
+UPDATE webhook_deliveries d SET status = 'pending', next_attempt_at = now()
+FROM webhook_subscriptions s
+WHERE d.id = $1 AND s.id = d.subscription_id AND s.company_id = $2;
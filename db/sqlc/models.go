@@ -6,17 +6,93 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type AccountDeletionRequest struct {
+	UserID       uuid.UUID
+	RequestedAt  time.Time
+	ScheduledFor time.Time
+	CompletedAt  sql.NullTime
+}
+
+type AnalyticsDailySignup struct {
+	Day     time.Time
+	Role    string
+	Signups int64
+}
+
+type ApplicantProfile struct {
+	ApplicantID       uuid.UUID
+	Headline          string
+	Location          string
+	WorkAuthorization string
+	YearsExperience   sql.NullInt32
+	GithubUrl         string
+	LinkedinUrl       string
+	PortfolioUrl      string
+	PreferredRoles    []string
+	PreferredSalary   sql.NullInt32
+	RemotePreference  string
+	UpdatedAt         time.Time
+}
+
+type ApplicantSkill struct {
+	ApplicantID uuid.UUID
+	SkillID     uuid.UUID
+	Proficiency string
+	Years       sql.NullInt32
+}
+
 type ApplicantSkillSet struct {
 	ApplicantID uuid.UUID
 	Skills      []string
 	CreatedAt   time.Time
 }
 
+type Application struct {
+	ID           uuid.UUID
+	JobPostingID uuid.UUID
+	ApplicantID  uuid.UUID
+	Stage        string
+	Source       string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type ApplicationAnswer struct {
+	ApplicationID uuid.UUID
+	QuestionID    uuid.NullUUID
+	Position      int32
+	Prompt        string
+	Answer        []string
+	KnockedOut    bool
+}
+
+type ApplicationStageEvent struct {
+	ID            int64
+	ApplicationID uuid.UUID
+	Stage         string
+	EnteredAt     time.Time
+}
+
+type AuditEvent struct {
+	ID         int64
+	ActorID    uuid.NullUUID
+	ActorEmail sql.NullString
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     json.RawMessage
+	After      json.RawMessage
+	Ip         sql.NullString
+	UserAgent  sql.NullString
+	CreatedAt  time.Time
+}
+
 type Company struct {
 	ID          uuid.UUID
 	RecruiterID uuid.NullUUID
@@ -24,18 +100,123 @@ type Company struct {
 	Description sql.NullString
 	Logo        sql.NullString
 	CreatedAt   time.Time
+	DeletedAt   sql.NullTime
+}
+
+type Education struct {
+	ID           uuid.UUID
+	ApplicantID  uuid.UUID
+	School       string
+	Degree       string
+	FieldOfStudy string
+	StartYear    int32
+	EndYear      sql.NullInt32
+	CreatedAt    time.Time
 }
 
 type JobPosting struct {
-	ID          uuid.UUID
-	RecruiterID uuid.NullUUID
-	CompanyID   uuid.NullUUID
-	CompanyName string
-	Position    string
-	Skills      []string
-	Description sql.NullString
-	Salary      sql.NullString
-	CreatedAt   sql.NullTime
+	ID              uuid.UUID
+	RecruiterID     uuid.NullUUID
+	CompanyID       uuid.NullUUID
+	CompanyName     string
+	Position        string
+	Skills          []string
+	Description     sql.NullString
+	Salary          sql.NullString
+	CreatedAt       sql.NullTime
+	DeletedAt       sql.NullTime
+	PreferredSkills []string
+}
+
+type JobPostingSkill struct {
+	JobPostingID uuid.UUID
+	SkillID      uuid.UUID
+	Required     bool
+}
+
+type JobPostingView struct {
+	ID           int64
+	JobPostingID uuid.UUID
+	ViewerID     uuid.NullUUID
+	Source       string
+	ViewedAt     time.Time
+	ViewedOn     time.Time
+}
+
+type Notification struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Kind      string
+	Subject   string
+	Body      string
+	Link      sql.NullString
+	ReadAt    sql.NullTime
+	CreatedAt time.Time
+}
+
+type Permission struct {
+	Name        string
+	Description string
+}
+
+type PersonalAccessToken struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	TokenHash  string
+	Prefix     string
+	Scopes     []string
+	ExpiresAt  sql.NullTime
+	LastUsedAt sql.NullTime
+	CreatedAt  time.Time
+}
+
+type RateLimitBucket struct {
+	Key       string
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+type Role struct {
+	Name        string
+	Description string
+}
+
+type RolePermission struct {
+	Role       string
+	Permission string
+}
+
+type SavedJob struct {
+	ApplicantID  uuid.UUID
+	JobPostingID uuid.UUID
+	CreatedAt    time.Time
+}
+
+type SavedSearch struct {
+	ID                 uuid.UUID
+	ApplicantID        uuid.UUID
+	Keywords           string
+	Skills             []string
+	MinSalary          sql.NullInt32
+	MatchProfileSkills bool
+	Frequency          string
+	LastAlertedAt      time.Time
+	CreatedAt          time.Time
+}
+
+type ScreeningQuestion struct {
+	ID              uuid.UUID
+	JobPostingID    uuid.UUID
+	Position        int32
+	Prompt          string
+	Kind            string
+	Options         []string
+	Required        bool
+	KnockoutAnswers []string
+	KnockoutMin     sql.NullInt32
+	KnockoutMax     sql.NullInt32
+	CreatedAt       time.Time
 }
 
 type Session struct {
@@ -44,11 +225,73 @@ type Session struct {
 	CreatedAt sql.NullTime
 }
 
-type User struct {
+type Skill struct {
 	ID        uuid.UUID
 	Name      string
-	Email     string
-	Picture   sql.NullString
-	Role      string
-	CreatedAt sql.NullTime
+	Slug      string
+	Category  string
+	CreatedAt time.Time
+	Curated   bool
+}
+
+type SkillAlias struct {
+	Alias   string
+	SkillID uuid.UUID
+}
+
+type SkillLookup struct {
+	Key     string
+	SkillID uuid.UUID
+}
+
+type User struct {
+	ID               uuid.UUID
+	Name             string
+	Email            string
+	Picture          sql.NullString
+	Role             string
+	CreatedAt        sql.NullTime
+	DeletedAt        sql.NullTime
+	Status           string
+	SuspendedAt      sql.NullTime
+	SuspendedUntil   sql.NullTime
+	SuspensionReason sql.NullString
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventType      string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int32
+	NextAttemptAt  time.Time
+	ResponseCode   sql.NullInt32
+	ResponseBody   sql.NullString
+	LastError      sql.NullString
+	CreatedAt      time.Time
+	DeliveredAt    sql.NullTime
+}
+
+type WebhookSubscription struct {
+	ID                  uuid.UUID
+	CompanyID           uuid.UUID
+	Url                 string
+	Secret              string
+	EventTypes          []string
+	Active              bool
+	ConsecutiveFailures int32
+	DisabledAt          sql.NullTime
+	CreatedAt           time.Time
+}
+
+type WorkExperience struct {
+	ID          uuid.UUID
+	ApplicantID uuid.UUID
+	Company     string
+	Title       string
+	StartDate   time.Time
+	EndDate     sql.NullTime
+	Description string
+	CreatedAt   time.Time
 }
@@ -14,7 +14,7 @@ import (
 )
 
 const approveRecruiter = `-- name: ApproveRecruiter :exec
-UPDATE users SET role = 'recruiter' WHERE id = $1
+UPDATE users SET status = 'active' WHERE id = $1
 `
 
 func (q *Queries) ApproveRecruiter(ctx context.Context, id uuid.UUID) error {
@@ -22,10 +22,52 @@ func (q *Queries) ApproveRecruiter(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const countSessions = `-- name: CountSessions :one
+SELECT count(*) FROM sessions
+`
+
+func (q *Queries) CountSessions(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSessions)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUsers = `-- name: CountUsers :one
+SELECT count(*) FROM users
+WHERE deleted_at IS NULL
+  AND ($1::text IS NULL OR name ILIKE '%' || $1 || '%' OR email ILIKE '%' || $1 || '%')
+  AND ($2::text IS NULL OR role = $2)
+  AND ($3::text IS NULL OR status = $3)
+  AND ($4::timestamptz IS NULL OR created_at >= $4)
+  AND ($5::timestamptz IS NULL OR created_at < $5)
+`
+
+type CountUsersParams struct {
+	Search     sql.NullString
+	Role       sql.NullString
+	Status     sql.NullString
+	JoinedFrom sql.NullTime
+	JoinedTo   sql.NullTime
+}
+
+func (q *Queries) CountUsers(ctx context.Context, arg CountUsersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUsers,
+		arg.Search,
+		arg.Role,
+		arg.Status,
+		arg.JoinedFrom,
+		arg.JoinedTo,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCompany = `-- name: CreateCompany :one
 INSERT INTO companies (id, recruiter_id, name, description, logo)
 VALUES ($1, $2, $3, $4, $5)
-RETURNING id, recruiter_id, name, description, logo, created_at
+RETURNING id, recruiter_id, name, description, logo, created_at, deleted_at
 `
 
 type CreateCompanyParams struct {
@@ -52,24 +94,26 @@ func (q *Queries) CreateCompany(ctx context.Context, arg CreateCompanyParams) (C
 		&i.Description,
 		&i.Logo,
 		&i.CreatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const createJobPost = `-- name: CreateJobPost :exec
-INSERT INTO job_postings (id, recruiter_id, company_id, company_name, position, skills, description, salary)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO job_postings (id, recruiter_id, company_id, company_name, position, skills, description, salary, preferred_skills)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 `
 
 type CreateJobPostParams struct {
-	ID          uuid.UUID
-	RecruiterID uuid.NullUUID
-	CompanyID   uuid.NullUUID
-	CompanyName string
-	Position    string
-	Skills      []string
-	Description sql.NullString
-	Salary      sql.NullString
+	ID              uuid.UUID
+	RecruiterID     uuid.NullUUID
+	CompanyID       uuid.NullUUID
+	CompanyName     string
+	Position        string
+	Skills          []string
+	Description     sql.NullString
+	Salary          sql.NullString
+	PreferredSkills []string
 }
 
 func (q *Queries) CreateJobPost(ctx context.Context, arg CreateJobPostParams) error {
@@ -82,6 +126,7 @@ func (q *Queries) CreateJobPost(ctx context.Context, arg CreateJobPostParams) er
 		pq.Array(arg.Skills),
 		arg.Description,
 		arg.Salary,
+		pq.Array(arg.PreferredSkills),
 	)
 	return err
 }
@@ -109,9 +154,9 @@ func (q *Queries) CreateOrUpdateSession(ctx context.Context, arg CreateOrUpdateS
 }
 
 const createUser = `-- name: CreateUser :one
-INSERT INTO users (name, email, picture, role)
-VALUES ($1, $2, $3, $4)
-RETURNING id, name, email, picture, role, created_at
+INSERT INTO users (name, email, picture, role, status)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, email, picture, role, created_at, deleted_at, status, suspended_at, suspended_until, suspension_reason
 `
 
 type CreateUserParams struct {
@@ -119,6 +164,7 @@ type CreateUserParams struct {
 	Email   string
 	Picture sql.NullString
 	Role    string
+	Status  string
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -127,6 +173,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.Email,
 		arg.Picture,
 		arg.Role,
+		arg.Status,
 	)
 	var i User
 	err := row.Scan(
@@ -136,12 +183,17 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Picture,
 		&i.Role,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.SuspendedAt,
+		&i.SuspendedUntil,
+		&i.SuspensionReason,
 	)
 	return i, err
 }
 
 const deleteJobPost = `-- name: DeleteJobPost :exec
-DELETE FROM job_postings WHERE id = $1
+UPDATE job_postings SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteJobPost(ctx context.Context, id uuid.UUID) error {
@@ -163,8 +215,24 @@ func (q *Queries) DeleteSession(ctx context.Context, arg DeleteSessionParams) er
 	return err
 }
 
+const deleteUserSessions = `-- name: DeleteUserSessions :exec
+DELETE FROM sessions WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserSessions(ctx context.Context, userID uuid.NullUUID) error {
+	_, err := q.db.ExecContext(ctx, deleteUserSessions, userID)
+	return err
+}
+
 const getAllJobPosts = `-- name: GetAllJobPosts :many
-SELECT id, recruiter_id, company_id, company_name, position, skills, description, salary, created_at FROM job_postings
+SELECT jp.id, jp.recruiter_id, jp.company_id, jp.company_name, jp.position, jp.skills, jp.description, jp.salary, jp.created_at, jp.deleted_at, jp.preferred_skills FROM job_postings jp
+WHERE jp.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM companies c WHERE c.id = jp.company_id AND c.deleted_at IS NOT NULL)
+  AND NOT EXISTS (
+    SELECT 1 FROM users u
+    WHERE u.id = jp.recruiter_id AND u.status = 'suspended'
+      AND (u.suspended_until IS NULL OR u.suspended_until > now())
+  )
 `
 
 func (q *Queries) GetAllJobPosts(ctx context.Context) ([]JobPosting, error) {
@@ -186,6 +254,8 @@ func (q *Queries) GetAllJobPosts(ctx context.Context) ([]JobPosting, error) {
 			&i.Description,
 			&i.Salary,
 			&i.CreatedAt,
+			&i.DeletedAt,
+			pq.Array(&i.PreferredSkills),
 		); err != nil {
 			return nil, err
 		}
@@ -200,33 +270,92 @@ func (q *Queries) GetAllJobPosts(ctx context.Context) ([]JobPosting, error) {
 	return items, nil
 }
 
-const getAllUsers = `-- name: GetAllUsers :many
-SELECT id, name, email, picture, role FROM users WHERE role IN ('applicant', 'recruiter')
+const getCompanyByID = `-- name: GetCompanyByID :one
+SELECT id, recruiter_id, name, description, logo, created_at, deleted_at FROM companies WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetCompanyByID(ctx context.Context, id uuid.UUID) (Company, error) {
+	row := q.db.QueryRowContext(ctx, getCompanyByID, id)
+	var i Company
+	err := row.Scan(
+		&i.ID,
+		&i.RecruiterID,
+		&i.Name,
+		&i.Description,
+		&i.Logo,
+		&i.CreatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getCompanyByRecruiterID = `-- name: GetCompanyByRecruiterID :one
+SELECT id, recruiter_id, name, description, logo, created_at, deleted_at FROM companies WHERE recruiter_id = $1 AND deleted_at IS NULL
 `
 
-type GetAllUsersRow struct {
+func (q *Queries) GetCompanyByRecruiterID(ctx context.Context, recruiterID uuid.NullUUID) (Company, error) {
+	row := q.db.QueryRowContext(ctx, getCompanyByRecruiterID, recruiterID)
+	var i Company
+	err := row.Scan(
+		&i.ID,
+		&i.RecruiterID,
+		&i.Name,
+		&i.Description,
+		&i.Logo,
+		&i.CreatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getJobPostByID = `-- name: GetJobPostByID :one
+SELECT id, recruiter_id, company_id, company_name, position, skills, description, salary, created_at, deleted_at, preferred_skills FROM job_postings WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetJobPostByID(ctx context.Context, id uuid.UUID) (JobPosting, error) {
+	row := q.db.QueryRowContext(ctx, getJobPostByID, id)
+	var i JobPosting
+	err := row.Scan(
+		&i.ID,
+		&i.RecruiterID,
+		&i.CompanyID,
+		&i.CompanyName,
+		&i.Position,
+		pq.Array(&i.Skills),
+		&i.Description,
+		&i.Salary,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		pq.Array(&i.PreferredSkills),
+	)
+	return i, err
+}
+
+const getPendingRecruiters = `-- name: GetPendingRecruiters :many
+SELECT id, name, email, picture FROM users WHERE role = 'recruiter' AND status = 'pending' AND deleted_at IS NULL
+`
+
+type GetPendingRecruitersRow struct {
 	ID      uuid.UUID
 	Name    string
 	Email   string
 	Picture sql.NullString
-	Role    string
 }
 
-func (q *Queries) GetAllUsers(ctx context.Context) ([]GetAllUsersRow, error) {
-	rows, err := q.db.QueryContext(ctx, getAllUsers)
+func (q *Queries) GetPendingRecruiters(ctx context.Context) ([]GetPendingRecruitersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingRecruiters)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetAllUsersRow
+	var items []GetPendingRecruitersRow
 	for rows.Next() {
-		var i GetAllUsersRow
+		var i GetPendingRecruitersRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
 			&i.Email,
 			&i.Picture,
-			&i.Role,
 		); err != nil {
 			return nil, err
 		}
@@ -241,49 +370,226 @@ func (q *Queries) GetAllUsers(ctx context.Context) ([]GetAllUsersRow, error) {
 	return items, nil
 }
 
-const getCompanyByRecruiterID = `-- name: GetCompanyByRecruiterID :one
-SELECT id, recruiter_id, name, description, logo, created_at FROM companies WHERE recruiter_id = $1
+const getSession = `-- name: GetSession :one
+SELECT user_id, token, created_at FROM sessions WHERE token = $1
 `
 
-func (q *Queries) GetCompanyByRecruiterID(ctx context.Context, recruiterID uuid.NullUUID) (Company, error) {
-	row := q.db.QueryRowContext(ctx, getCompanyByRecruiterID, recruiterID)
-	var i Company
+func (q *Queries) GetSession(ctx context.Context, token string) (Session, error) {
+	row := q.db.QueryRowContext(ctx, getSession, token)
+	var i Session
+	err := row.Scan(&i.UserID, &i.Token, &i.CreatedAt)
+	return i, err
+}
+
+const getSessionUser = `-- name: GetSessionUser :one
+SELECT u.id, u.role, u.status
+FROM sessions s
+JOIN users u ON u.id = s.user_id
+WHERE s.token = $1 AND u.deleted_at IS NULL
+`
+
+type GetSessionUserRow struct {
+	ID     uuid.UUID
+	Role   string
+	Status string
+}
+
+func (q *Queries) GetSessionUser(ctx context.Context, token string) (GetSessionUserRow, error) {
+	row := q.db.QueryRowContext(ctx, getSessionUser, token)
+	var i GetSessionUserRow
+	err := row.Scan(&i.ID, &i.Role, &i.Status)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, name, email, picture, role, created_at, deleted_at, status, suspended_at, suspended_until, suspension_reason FROM users WHERE email = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+	var i User
 	err := row.Scan(
 		&i.ID,
-		&i.RecruiterID,
 		&i.Name,
+		&i.Email,
+		&i.Picture,
+		&i.Role,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.SuspendedAt,
+		&i.SuspendedUntil,
+		&i.SuspensionReason,
+	)
+	return i, err
+}
+
+const getUserByID = `-- name: GetUserByID :one
+SELECT id, name, email, picture, role, created_at, deleted_at, status, suspended_at, suspended_until, suspension_reason FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByID, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.Picture,
+		&i.Role,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.SuspendedAt,
+		&i.SuspendedUntil,
+		&i.SuspensionReason,
+	)
+	return i, err
+}
+
+const getVisibleJobPost = `-- name: GetVisibleJobPost :one
+SELECT jp.id, jp.recruiter_id, jp.company_id, jp.company_name, jp.position, jp.skills, jp.description, jp.salary, jp.created_at, jp.deleted_at, jp.preferred_skills FROM job_postings jp
+WHERE jp.id = $1
+  AND jp.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM companies c WHERE c.id = jp.company_id AND c.deleted_at IS NOT NULL)
+  AND NOT EXISTS (
+    SELECT 1 FROM users u
+    WHERE u.id = jp.recruiter_id AND u.status = 'suspended'
+      AND (u.suspended_until IS NULL OR u.suspended_until > now())
+  )
+`
+
+func (q *Queries) GetVisibleJobPost(ctx context.Context, id uuid.UUID) (JobPosting, error) {
+	row := q.db.QueryRowContext(ctx, getVisibleJobPost, id)
+	var i JobPosting
+	err := row.Scan(
+		&i.ID,
+		&i.RecruiterID,
+		&i.CompanyID,
+		&i.CompanyName,
+		&i.Position,
+		pq.Array(&i.Skills),
 		&i.Description,
-		&i.Logo,
+		&i.Salary,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		pq.Array(&i.PreferredSkills),
 	)
 	return i, err
 }
 
-const getPendingRecruiters = `-- name: GetPendingRecruiters :many
-SELECT id, name, email, picture FROM users WHERE role = 'pending'
+const listDeletedCompanies = `-- name: ListDeletedCompanies :many
+SELECT id, recruiter_id, name, deleted_at FROM companies
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
 `
 
-type GetPendingRecruitersRow struct {
-	ID      uuid.UUID
-	Name    string
-	Email   string
-	Picture sql.NullString
+type ListDeletedCompaniesRow struct {
+	ID          uuid.UUID
+	RecruiterID uuid.NullUUID
+	Name        string
+	DeletedAt   sql.NullTime
 }
 
-func (q *Queries) GetPendingRecruiters(ctx context.Context) ([]GetPendingRecruitersRow, error) {
-	rows, err := q.db.QueryContext(ctx, getPendingRecruiters)
+func (q *Queries) ListDeletedCompanies(ctx context.Context) ([]ListDeletedCompaniesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listDeletedCompanies)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetPendingRecruitersRow
+	var items []ListDeletedCompaniesRow
 	for rows.Next() {
-		var i GetPendingRecruitersRow
+		var i ListDeletedCompaniesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RecruiterID,
+			&i.Name,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeletedJobPosts = `-- name: ListDeletedJobPosts :many
+SELECT id, company_name, position, deleted_at FROM job_postings
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
+`
+
+type ListDeletedJobPostsRow struct {
+	ID          uuid.UUID
+	CompanyName string
+	Position    string
+	DeletedAt   sql.NullTime
+}
+
+func (q *Queries) ListDeletedJobPosts(ctx context.Context) ([]ListDeletedJobPostsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listDeletedJobPosts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDeletedJobPostsRow
+	for rows.Next() {
+		var i ListDeletedJobPostsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CompanyName,
+			&i.Position,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeletedUsers = `-- name: ListDeletedUsers :many
+SELECT id, name, email, role, deleted_at FROM users
+WHERE deleted_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM account_deletion_requests r WHERE r.user_id = users.id AND r.completed_at IS NOT NULL)
+ORDER BY deleted_at DESC
+`
+
+type ListDeletedUsersRow struct {
+	ID        uuid.UUID
+	Name      string
+	Email     string
+	Role      string
+	DeletedAt sql.NullTime
+}
+
+func (q *Queries) ListDeletedUsers(ctx context.Context) ([]ListDeletedUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listDeletedUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDeletedUsersRow
+	for rows.Next() {
+		var i ListDeletedUsersRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
 			&i.Email,
-			&i.Picture,
+			&i.Role,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -298,23 +604,59 @@ func (q *Queries) GetPendingRecruiters(ctx context.Context) ([]GetPendingRecruit
 	return items, nil
 }
 
-const getSession = `-- name: GetSession :one
-SELECT user_id, token, created_at FROM sessions WHERE token = $1
+const listSuspendedUsers = `-- name: ListSuspendedUsers :many
+SELECT id, name, email, role, suspended_at, suspended_until, suspension_reason FROM users
+WHERE status = 'suspended' AND deleted_at IS NULL
+ORDER BY suspended_at DESC
 `
 
-func (q *Queries) GetSession(ctx context.Context, token string) (Session, error) {
-	row := q.db.QueryRowContext(ctx, getSession, token)
-	var i Session
-	err := row.Scan(&i.UserID, &i.Token, &i.CreatedAt)
-	return i, err
+type ListSuspendedUsersRow struct {
+	ID               uuid.UUID
+	Name             string
+	Email            string
+	Role             string
+	SuspendedAt      sql.NullTime
+	SuspendedUntil   sql.NullTime
+	SuspensionReason sql.NullString
 }
 
-const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, name, email, picture, role, created_at FROM users WHERE email = $1
+func (q *Queries) ListSuspendedUsers(ctx context.Context) ([]ListSuspendedUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSuspendedUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSuspendedUsersRow
+	for rows.Next() {
+		var i ListSuspendedUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Role,
+			&i.SuspendedAt,
+			&i.SuspendedUntil,
+			&i.SuspensionReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockUser = `-- name: LockUser :one
+SELECT id, name, email, picture, role, created_at, deleted_at, status, suspended_at, suspended_until, suspension_reason FROM users WHERE id = $1 FOR UPDATE
 `
 
-func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+func (q *Queries) LockUser(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRowContext(ctx, lockUser, id)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -323,12 +665,58 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Picture,
 		&i.Role,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.SuspendedAt,
+		&i.SuspendedUntil,
+		&i.SuspensionReason,
 	)
 	return i, err
 }
 
+const purgeDeletedCompanies = `-- name: PurgeDeletedCompanies :execrows
+DELETE FROM companies WHERE deleted_at < $1
+  AND NOT EXISTS (SELECT 1 FROM job_postings p JOIN applications a ON a.job_posting_id = p.id WHERE p.company_id = companies.id)
+`
+
+func (q *Queries) PurgeDeletedCompanies(ctx context.Context, deletedAt sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeDeletedCompanies, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeDeletedJobPosts = `-- name: PurgeDeletedJobPosts :execrows
+DELETE FROM job_postings WHERE deleted_at < $1
+  AND NOT EXISTS (SELECT 1 FROM applications a WHERE a.job_posting_id = job_postings.id)
+`
+
+func (q *Queries) PurgeDeletedJobPosts(ctx context.Context, deletedAt sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeDeletedJobPosts, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeDeletedUsers = `-- name: PurgeDeletedUsers :execrows
+DELETE FROM users WHERE deleted_at < $1
+  AND NOT EXISTS (SELECT 1 FROM account_deletion_requests r WHERE r.user_id = users.id AND r.completed_at IS NOT NULL)
+  AND NOT EXISTS (SELECT 1 FROM applications a WHERE a.applicant_id = users.id)
+  AND NOT EXISTS (SELECT 1 FROM job_postings p JOIN applications a ON a.job_posting_id = p.id WHERE p.recruiter_id = users.id)
+`
+
+func (q *Queries) PurgeDeletedUsers(ctx context.Context, deletedAt sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeDeletedUsers, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const rejectCompany = `-- name: RejectCompany :exec
-DELETE FROM companies WHERE recruiter_id = $1
+UPDATE companies SET deleted_at = now() WHERE recruiter_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) RejectCompany(ctx context.Context, recruiterID uuid.NullUUID) error {
@@ -337,7 +725,7 @@ func (q *Queries) RejectCompany(ctx context.Context, recruiterID uuid.NullUUID)
 }
 
 const rejectRecruiter = `-- name: RejectRecruiter :exec
-DELETE FROM users WHERE id = $1
+UPDATE users SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) RejectRecruiter(ctx context.Context, id uuid.UUID) error {
@@ -345,6 +733,143 @@ func (q *Queries) RejectRecruiter(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const restoreCompany = `-- name: RestoreCompany :exec
+UPDATE companies SET deleted_at = NULL WHERE id = $1
+`
+
+func (q *Queries) RestoreCompany(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, restoreCompany, id)
+	return err
+}
+
+const restoreJobPost = `-- name: RestoreJobPost :exec
+UPDATE job_postings SET deleted_at = NULL WHERE id = $1
+`
+
+func (q *Queries) RestoreJobPost(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, restoreJobPost, id)
+	return err
+}
+
+const restoreUser = `-- name: RestoreUser :exec
+UPDATE users SET deleted_at = NULL WHERE id = $1
+`
+
+func (q *Queries) RestoreUser(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, restoreUser, id)
+	return err
+}
+
+const searchUsers = `-- name: SearchUsers :many
+SELECT id, name, email, role, status, created_at, suspended_until FROM users
+WHERE deleted_at IS NULL
+  AND ($1::text IS NULL OR name ILIKE '%' || $1 || '%' OR email ILIKE '%' || $1 || '%')
+  AND ($2::text IS NULL OR role = $2)
+  AND ($3::text IS NULL OR status = $3)
+  AND ($4::timestamptz IS NULL OR created_at >= $4)
+  AND ($5::timestamptz IS NULL OR created_at < $5)
+ORDER BY
+  CASE WHEN $6::text = 'name' AND NOT $7::bool THEN name END ASC,
+  CASE WHEN $6 = 'name' AND $7 THEN name END DESC,
+  CASE WHEN $6 = 'email' AND NOT $7 THEN email END ASC,
+  CASE WHEN $6 = 'email' AND $7 THEN email END DESC,
+  CASE WHEN $6 = 'joined' AND NOT $7 THEN created_at END ASC,
+  CASE WHEN $6 = 'joined' AND $7 THEN created_at END DESC,
+  id
+LIMIT $8 OFFSET $9
+`
+
+type SearchUsersParams struct {
+	Search     sql.NullString
+	Role       sql.NullString
+	Status     sql.NullString
+	JoinedFrom sql.NullTime
+	JoinedTo   sql.NullTime
+	Sort       string
+	Descending bool
+	RowLimit   int32
+	RowOffset  int32
+}
+
+type SearchUsersRow struct {
+	ID             uuid.UUID
+	Name           string
+	Email          string
+	Role           string
+	Status         string
+	CreatedAt      sql.NullTime
+	SuspendedUntil sql.NullTime
+}
+
+func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchUsers,
+		arg.Search,
+		arg.Role,
+		arg.Status,
+		arg.JoinedFrom,
+		arg.JoinedTo,
+		arg.Sort,
+		arg.Descending,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchUsersRow
+	for rows.Next() {
+		var i SearchUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Role,
+			&i.Status,
+			&i.CreatedAt,
+			&i.SuspendedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const suspendUser = `-- name: SuspendUser :exec
+UPDATE users
+SET status = 'suspended', suspended_at = now(), suspended_until = $2, suspension_reason = $3
+WHERE id = $1
+`
+
+type SuspendUserParams struct {
+	ID               uuid.UUID
+	SuspendedUntil   sql.NullTime
+	SuspensionReason sql.NullString
+}
+
+func (q *Queries) SuspendUser(ctx context.Context, arg SuspendUserParams) error {
+	_, err := q.db.ExecContext(ctx, suspendUser, arg.ID, arg.SuspendedUntil, arg.SuspensionReason)
+	return err
+}
+
+const unsuspendUser = `-- name: UnsuspendUser :exec
+UPDATE users
+SET status = 'active', suspended_at = NULL, suspended_until = NULL, suspension_reason = NULL
+WHERE id = $1 AND status = 'suspended'
+`
+
+func (q *Queries) UnsuspendUser(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, unsuspendUser, id)
+	return err
+}
+
 const updateApplicantSkills = `-- name: UpdateApplicantSkills :exec
 INSERT INTO applicant_skill_sets (applicant_id, skills)
 VALUES ($1, $2) ON CONFLICT (applicant_id) DO UPDATE SET skills = $2
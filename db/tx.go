@@ -0,0 +1,30 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	sqlc "gin-app/db/sqlc"
+)
+
+// TxBeginner is satisfied by *sql.DB and *sql.Conn, so a service can be
+// pinned to one connection. A *sql.Tx is not one: Postgres transactions do
+// not nest, and InTx always begins and commits its own. Tests therefore run
+// services against a database of their own, named by TEST_DATABASE_URL,
+// and create the rows they need.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// InTx runs fn with instrumented queries bound to a new transaction. The
+// transaction is committed if fn returns nil and rolled back otherwise.
+func InTx(ctx context.Context, conn TxBeginner, fn func(*sqlc.Queries) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := fn(sqlc.New(Instrument(tx))); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
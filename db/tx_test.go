@@ -0,0 +1,45 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"gin-app/db"
+	"gin-app/db/dbtest"
+	sqlc "gin-app/db/sqlc"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestInTx(t *testing.T) {
+	conn := dbtest.Open(t)
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name    string
+		err     error
+		written bool
+	}{
+		{"commits when fn succeeds", nil, true},
+		{"rolls back when fn fails", errFailed, false},
+	}
+	for _, tt := range tests {
+		email := uuid.NewString() + "@example.com"
+		err := db.InTx(ctx, conn, func(q *sqlc.Queries) error {
+			user, err := q.CreateUser(ctx, sqlc.CreateUserParams{Name: "Test", Email: email, Role: "applicant", Status: "active"})
+			if err != nil {
+				return err
+			}
+			t.Cleanup(func() { conn.Exec("DELETE FROM users WHERE id = $1", user.ID) })
+			return tt.err
+		})
+		if !errors.Is(err, tt.err) {
+			t.Fatalf("%s: InTx returned %v, want %v", tt.name, err, tt.err)
+		}
+		_, err = sqlc.New(conn).GetUserByEmail(ctx, email)
+		if written := err == nil; written != tt.written {
+			t.Errorf("%s: user written = %v (%v), want %v", tt.name, written, err, tt.written)
+		}
+	}
+}
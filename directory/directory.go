@@ -0,0 +1,301 @@
+package directory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"gin-app/authz"
+	sqlc "gin-app/db/sqlc"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// PageSize is the number of users on one page of the directory.
+	PageSize = 25
+	// exportBatch is the number of rows the CSV export reads per query, so
+	// exporting a large directory never holds it all in memory.
+	exportBatch = 500
+	dateLayout  = "2006-01-02"
+)
+
+var (
+	Roles    = []string{"applicant", "recruiter", "admin"}
+	Statuses = []string{authz.StatusActive, authz.StatusPending, authz.StatusSuspended}
+	// Sorts are the columns the directory can be sorted by.
+	Sorts = []string{"name", "email", "joined"}
+)
+
+var ErrUserNotFound = errors.New("user not found")
+
+// Service backs the admin user directory.
+type Service struct {
+	Queries *sqlc.Queries
+}
+
+func NewService(queries *sqlc.Queries) *Service {
+	return &Service{Queries: queries}
+}
+
+// Filter is a directory search as given in the query string. Unknown roles,
+// statuses, sorts and malformed dates are ignored rather than rejected, so a
+// stale bookmark still shows something.
+type Filter struct {
+	Search     string
+	Role       string
+	Status     string
+	JoinedFrom string
+	JoinedTo   string
+	Sort       string
+	Descending bool
+	Page       int
+}
+
+// ParseFilter reads a Filter from query parameters. Users are listed newest
+// first unless another sort is asked for.
+func ParseFilter(query url.Values) Filter {
+	f := Filter{
+		Search:     strings.TrimSpace(query.Get("q")),
+		Role:       oneOf(query.Get("role"), Roles),
+		Status:     oneOf(query.Get("status"), Statuses),
+		JoinedFrom: date(query.Get("joined_from")),
+		JoinedTo:   date(query.Get("joined_to")),
+		Sort:       oneOf(query.Get("sort"), Sorts),
+		Descending: query.Get("order") == "desc",
+		Page:       1,
+	}
+	if f.Sort == "" {
+		f.Sort, f.Descending = "joined", true
+	}
+	if page, err := strconv.Atoi(query.Get("page")); err == nil && page > 1 {
+		f.Page = page
+	}
+	return f
+}
+
+// Query encodes the filter back into query parameters, without the page.
+func (f Filter) Query() url.Values {
+	query := url.Values{}
+	for key, value := range map[string]string{
+		"q":           f.Search,
+		"role":        f.Role,
+		"status":      f.Status,
+		"joined_from": f.JoinedFrom,
+		"joined_to":   f.JoinedTo,
+		"sort":        f.Sort,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if f.Descending {
+		query.Set("order", "desc")
+	}
+	return query
+}
+
+// PageURL links to page of the filtered directory.
+func (f Filter) PageURL(page int) string {
+	query := f.Query()
+	if page > 1 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	return "/admin/view-users?" + query.Encode()
+}
+
+// SortURL links to the first page sorted by column, reversing the order if
+// the directory is already sorted by it.
+func (f Filter) SortURL(column string) string {
+	sorted := f
+	sorted.Descending = f.Sort == column && !f.Descending
+	sorted.Sort = column
+	return sorted.PageURL(1)
+}
+
+// ExportURL links to the CSV export of the filtered directory.
+func (f Filter) ExportURL() string {
+	return "/admin/view-users/export.csv?" + f.Query().Encode()
+}
+
+func (f Filter) params() sqlc.SearchUsersParams {
+	params := sqlc.SearchUsersParams{
+		Search:     sql.NullString{String: escapeLike(f.Search), Valid: f.Search != ""},
+		Role:       sql.NullString{String: f.Role, Valid: f.Role != ""},
+		Status:     sql.NullString{String: f.Status, Valid: f.Status != ""},
+		Sort:       f.Sort,
+		Descending: f.Descending,
+	}
+	if from, err := time.Parse(dateLayout, f.JoinedFrom); err == nil {
+		params.JoinedFrom = sql.NullTime{Time: from, Valid: true}
+	}
+	if to, err := time.Parse(dateLayout, f.JoinedTo); err == nil {
+		params.JoinedTo = sql.NullTime{Time: to.AddDate(0, 0, 1), Valid: true}
+	}
+	return params
+}
+
+// Page is one page of directory results. Prev and Next are the neighbouring
+// page numbers, or 0 if there is none.
+type Page struct {
+	Users []sqlc.SearchUsersRow
+	Total int64
+	Page  int
+	Pages int
+	Prev  int
+	Next  int
+}
+
+// Search returns the page of users matching f.
+func (s *Service) Search(ctx context.Context, f Filter) (Page, error) {
+	params := f.params()
+	total, err := s.Queries.CountUsers(ctx, sqlc.CountUsersParams{
+		Search:     params.Search,
+		Role:       params.Role,
+		Status:     params.Status,
+		JoinedFrom: params.JoinedFrom,
+		JoinedTo:   params.JoinedTo,
+	})
+	if err != nil {
+		return Page{}, err
+	}
+	params.RowLimit = PageSize
+	params.RowOffset = int32((f.Page - 1) * PageSize)
+	users, err := s.Queries.SearchUsers(ctx, params)
+	if err != nil {
+		return Page{}, err
+	}
+	page := Page{
+		Users: users,
+		Total: total,
+		Page:  f.Page,
+		Pages: max(1, int((total+PageSize-1)/PageSize)),
+	}
+	if page.Page > 1 {
+		page.Prev = page.Page - 1
+	}
+	if page.Page < page.Pages {
+		page.Next = page.Page + 1
+	}
+	return page, nil
+}
+
+// ExportCSV writes every user matching f, in the filter's order, as CSV.
+func (s *Service) ExportCSV(ctx context.Context, w io.Writer, f Filter) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"id", "name", "email", "role", "status", "joined_at", "suspended_until"}); err != nil {
+		return err
+	}
+	params := f.params()
+	params.RowLimit = exportBatch
+	for {
+		users, err := s.Queries.SearchUsers(ctx, params)
+		if err != nil {
+			return err
+		}
+		for _, u := range users {
+			err := cw.Write([]string{
+				u.ID.String(),
+				cell(u.Name),
+				cell(u.Email),
+				u.Role,
+				u.Status,
+				csvTime(u.CreatedAt),
+				csvTime(u.SuspendedUntil),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		if len(users) < exportBatch {
+			return nil
+		}
+		params.RowOffset += exportBatch
+	}
+}
+
+// Detail is everything the admin user page shows about one user.
+type Detail struct {
+	User         sqlc.User
+	Company      *sqlc.Company
+	Postings     []sqlc.ListJobPostsByRecruiterRow
+	Applications []sqlc.ListApplicationsByApplicantRow
+	Sessions     []sql.NullTime
+}
+
+// Detail loads a user with their company, postings, applications and
+// sessions. Soft-deleted users are included so admins can look them up.
+func (s *Service) Detail(ctx context.Context, id uuid.UUID) (Detail, error) {
+	var d Detail
+	var err error
+	d.User, err = s.Queries.GetUserByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return d, ErrUserNotFound
+		}
+		return d, err
+	}
+	nullID := uuid.NullUUID{UUID: id, Valid: true}
+	company, err := s.Queries.GetCompanyByRecruiterID(ctx, nullID)
+	switch {
+	case err == nil:
+		d.Company = &company
+	case !errors.Is(err, sql.ErrNoRows):
+		return d, err
+	}
+	if d.Postings, err = s.Queries.ListJobPostsByRecruiter(ctx, nullID); err != nil {
+		return d, err
+	}
+	if d.Applications, err = s.Queries.ListApplicationsByApplicant(ctx, id); err != nil {
+		return d, err
+	}
+	if d.Sessions, err = s.Queries.ListUserSessions(ctx, nullID); err != nil {
+		return d, err
+	}
+	return d, nil
+}
+
+func oneOf(value string, allowed []string) string {
+	if slices.Contains(allowed, value) {
+		return value
+	}
+	return ""
+}
+
+func date(value string) string {
+	if _, err := time.Parse(dateLayout, value); err != nil {
+		return ""
+	}
+	return value
+}
+
+// escapeLike escapes the ILIKE wildcards in a search term so they match
+// literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// cell neutralizes values a spreadsheet would run as a formula.
+func cell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func csvTime(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.UTC().Format(time.RFC3339)
+}
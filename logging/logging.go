@@ -0,0 +1,143 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HeaderRequestID carries the correlation ID. An incoming value is reused so
+// a request can be traced across services; otherwise one is generated.
+const HeaderRequestID = "X-Request-ID"
+
+const requestIDKey = "request_id"
+
+type ctxKey struct{}
+
+// redactedKeys are attribute keys whose values are hidden when redaction is
+// on. Emails keep their domain so logs stay useful for support.
+var redactedKeys = map[string]bool{
+	"email":         true,
+	"actor_email":   true,
+	"token":         true,
+	"authorization": true,
+	"secret":        true,
+}
+
+// Setup installs a JSON slog logger as the default for both slog and the
+// standard log package.
+func Setup(level string, redact bool) *slog.Logger {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		lvl = slog.LevelInfo
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+	if redact {
+		opts.ReplaceAttr = redactAttr
+	}
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, opts))
+	slog.SetDefault(logger)
+	return logger
+}
+
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	if !redactedKeys[strings.ToLower(a.Key)] {
+		return a
+	}
+	value := a.Value.String()
+	if at := strings.LastIndex(value, "@"); at > 0 && strings.Contains(a.Key, "email") {
+		return slog.String(a.Key, "***"+value[at:])
+	}
+	return slog.String(a.Key, "[redacted]")
+}
+
+// FromContext returns the request logger stored in ctx, or the default.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// FromGin returns the request logger with the signed-in user's id and role
+// attached, as known at the time of the call.
+func FromGin(c *gin.Context) *slog.Logger {
+	logger := FromContext(c.Request.Context())
+	if id, role := user(c); id != "" {
+		logger = logger.With("user_id", id, "role", role)
+	}
+	return logger
+}
+
+// Middleware assigns the request ID, exposes a request-scoped logger through
+// the request context and logs one line per request when it completes.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		requestID := c.GetHeader(HeaderRequestID)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = uuid.NewString()
+		}
+		c.Set(requestIDKey, requestID)
+		c.Header(HeaderRequestID, requestID)
+
+		logger := slog.Default().With(requestIDKey, requestID)
+		if sc := trace.SpanContextFromContext(c.Request.Context()); sc.IsValid() {
+			logger = logger.With("trace_id", sc.TraceID().String())
+		}
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ctxKey{}, logger))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []any{
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+			"path", c.Request.URL.Path,
+			"status", status,
+			"latency_ms", time.Since(start).Milliseconds(),
+			"client_ip", c.ClientIP(),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "errors", c.Errors.String())
+		}
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		FromGin(c).Log(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// RequestID returns the correlation ID of the current request.
+func RequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// user prefers the identity set by the auth middlewares and falls back to
+// the cookie session when one was loaded for this route.
+func user(c *gin.Context) (string, string) {
+	id := c.GetString("id")
+	role, _ := c.Get("role")
+	if id == "" {
+		if _, ok := c.Get(sessions.DefaultKey); ok {
+			session := sessions.Default(c)
+			id, _ = session.Get("id").(string)
+			if role == nil {
+				role = session.Get("role")
+			}
+		}
+	}
+	roleName, _ := role.(string)
+	return id, roleName
+}
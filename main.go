@@ -3,91 +3,264 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"gin-app/accounts"
+	"gin-app/alerts"
+	"gin-app/analytics"
+	"gin-app/api"
+	"gin-app/audit"
 	"gin-app/auth"
+	"gin-app/authz"
+	"gin-app/config"
 	db "gin-app/db"
 	sqlc "gin-app/db/sqlc"
+	"gin-app/directory"
+	"gin-app/health"
+	"gin-app/logging"
+	"gin-app/metrics"
 	"gin-app/middlewares"
-	"log"
+	"gin-app/notify"
+	"gin-app/privacy"
+	"gin-app/profiles"
+	"gin-app/ratelimit"
+	"gin-app/retention"
+	"gin-app/screening"
+	"gin-app/skills"
+	"gin-app/tracing"
+	"gin-app/views"
+	"gin-app/webhooks"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 func main() {
-	DB := db.NewDB()
-	queries := sqlc.New(DB)
-	service := auth.NewService(queries)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrate(os.Args[2:])
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fatal("loading configuration", err)
+	}
+	logging.Setup(cfg.Log.Level, cfg.Log.Redact)
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		fatal("setting up tracing", err)
+	}
+
+	DB, err := db.NewDB(cfg.Database)
+	if err != nil {
+		fatal("connecting to database", err)
+	}
+	if err := db.EnsureSchema(DB, cfg.Database.AutoMigrate); err != nil {
+		fatal("checking database schema", err)
+	}
+
+	queries := sqlc.New(db.Instrument(DB))
+	service := auth.NewService(queries, cfg)
+	webhooksService := webhooks.NewService(queries)
+	recorder := audit.NewRecorder(queries)
+	permissions := authz.NewStore(queries)
+	accountsService := accounts.NewService(DB)
+	directoryService := directory.NewService(queries)
+	analyticsService := analytics.NewService(queries)
+	profileService := profiles.NewService(queries)
+	skillsService := skills.NewService(DB, queries)
+	screeningService := screening.NewService(DB, queries)
+	apiService := api.NewService(queries, webhooksService, skillsService, recorder)
+
+	privacyService := privacy.NewService(DB, queries, time.Duration(cfg.AccountDeletionGraceDays)*24*time.Hour)
+	checker := health.NewChecker(DB)
+
+	notifier := notify.Multi{notify.NewStore(queries)}
+	if cfg.Mail.SMTPHost != "" {
+		notifier = append(notifier, notify.NewMailer(cfg.Mail))
+	}
+
+	metrics.RegisterDB(DB, queries.CountSessions)
+
+	// Background workers get their own context, cancelled only after the
+	// HTTP server has drained, so requests in flight can still enqueue work.
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	runWorker := func(run func(context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(workerCtx)
+		}()
+	}
+	runWorker(webhooks.NewWorker(queries).Run)
+	runWorker(retention.NewPurger(queries, recorder, time.Duration(cfg.SoftDeleteRetentionDays)*24*time.Hour).Run)
+	runWorker(privacy.NewWorker(privacyService, recorder).Run)
+	runWorker(analytics.NewRefresher(queries, cfg.AnalyticsRefreshInterval).Run)
+	runWorker(alerts.NewWorker(queries, notifier, cfg.Mail.BaseURL, cfg.JobAlertInterval).Run)
+
+	var rateStore ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.Security.RateLimit.Store == "postgres" {
+		pgStore := ratelimit.NewPostgresStore(queries)
+		runWorker(pgStore.Run)
+		rateStore = pgStore
+	}
+	limiters := ratelimit.NewLimiters(rateStore, cfg.Security.RateLimit)
 
-	if os.Getenv("GIN_MODE") == "release" {
-		gin.SetMode(gin.ReleaseMode)
+	gin.SetMode(cfg.GinMode)
+
+	r := gin.New()
+	if err := r.SetTrustedProxies(cfg.Security.TrustedProxies); err != nil {
+		fatal("setting trusted proxies", err)
+	}
+	r.Use(gin.Recovery())
+
+	// Probes are registered before the logging, session and audit
+	// middlewares so they stay cheap, quiet and never touch cookies.
+	r.GET("/healthz", checker.LivenessHandler)
+	r.GET("/readyz", checker.ReadinessHandler)
+	switch {
+	case cfg.Metrics.Addr != "":
+		slog.Info("serving metrics on a separate listener", "addr", cfg.Metrics.Addr)
+	case cfg.Metrics.Token != "":
+		r.GET("/metrics", metrics.Handler(cfg.Metrics.Token))
+	default:
+		slog.Warn("not serving metrics; set METRICS_ADDR or METRICS_TOKEN to enable them")
 	}
 
-	r := gin.Default()
+	r.Use(otelgin.Middleware(cfg.Tracing.ServiceName))
+	r.Use(logging.Middleware())
+	r.Use(metrics.Middleware())
+	r.Use(middlewares.SecurityHeaders(cfg.Security))
+	r.Use(middlewares.BodyLimit(cfg.Security.MaxBodyBytes, cfg.Security.MaxUploadBytes))
 
-	secret := os.Getenv("SESSION_SECRET")
-	store := cookie.NewStore([]byte(secret))
+	store := cookie.NewStore([]byte(cfg.SessionSecret))
 	store.Options(sessions.Options{
-		MaxAge: 86400,
-		Path:   "/",
+		MaxAge:   86400,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   cfg.GinMode == gin.ReleaseMode,
+		SameSite: http.SameSiteLaxMode,
 	})
+	r.Use(middlewares.RequestTimeout(cfg.HTTP.RequestTimeout))
 	r.Use(sessions.Sessions("mysession", store))
+	r.Use(recorder.Middleware())
+	r.Use(middlewares.CSRFMiddleware())
 
 	r.Static("/static", "./static")
 
+	r.SetFuncMap(views.FuncMap)
 	r.LoadHTMLGlob("templates/**/*")
 
 	r.GET("/", func(c *gin.Context) {
 		session := sessions.Default(c)
 		userEmail := session.Get("email")
 		userName := session.Get("name")
-		userRole := session.Get("role")
+		userRole, _ := session.Get("role").(string)
+		userStatus, _ := session.Get("status").(string)
 
 		if userEmail == nil {
-			c.HTML(http.StatusOK, "home.html", gin.H{
+			views.HTML(c, http.StatusOK, "home.html", gin.H{
 				"title": "Recruitment Portal",
 				"user":  nil,
 			})
 			return
 		}
 
-		c.HTML(http.StatusOK, "home.html", gin.H{
+		views.HTML(c, http.StatusOK, "home.html", gin.H{
 			"title": "Recruitment Portal",
 			"user": gin.H{
-				"email": userEmail,
-				"name":  userName,
-				"role":  userRole,
+				"email":     userEmail,
+				"name":      userName,
+				"role":      userRole,
+				"dashboard": cfg.Redirects.ForUser(userRole, userStatus),
 			},
 		})
 	})
 
 	// login routes
 
-	r.GET("/auth/google/login", service.LoginHandler)
-	r.GET("/auth/google/login/:role", service.LoginHandler)
-	r.GET("/auth/google/callback", service.AuthHandler)
+	authRoutes := r.Group("/auth/google", limiters.Auth.Middleware())
+	authRoutes.GET("/login", service.LoginHandler)
+	authRoutes.GET("/login/:role", service.LoginHandler)
+	authRoutes.GET("/callback", service.AuthHandler)
 	r.GET("/auth/logout", service.LogoutHandler)
 
 	// applicant routes
 
 	applicantRoutes := r.Group("/applicant")
-	applicantRoutes.Use(middlewares.AuthMiddleware(), middlewares.ApplicantOnlyMiddleware())
+	applicantRoutes.Use(middlewares.AuthMiddleware(service, permissions), middlewares.RequirePermission(authz.JobsApply))
 
 	applicantRoutes.GET("/dashboard", func(c *gin.Context) {
 		session := sessions.Default(c)
 		userName := session.Get("name")
 		pictureURL := session.Get("picture").(string)
-		jobPosts, err := queries.GetAllJobPosts(context.Background())
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		jobPosts, err := queries.GetAllJobPosts(c.Request.Context())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		appliedIDs, err := queries.ListAppliedJobPostIDs(c.Request.Context(), uid)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		applied := make(map[uuid.UUID]bool, len(appliedIDs))
+		for _, id := range appliedIDs {
+			applied[id] = true
+		}
+		savedIDs, err := queries.ListSavedJobPostIDs(c.Request.Context(), uid)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		log.Println("pictureURL:", pictureURL)
-		c.HTML(http.StatusOK, "dashboard.html", gin.H{
+		saved := make(map[uuid.UUID]bool, len(savedIDs))
+		for _, id := range savedIDs {
+			saved[id] = true
+		}
+		// Postings with screening questions are applied to from their job
+		// page, where the questions are asked.
+		screenedIDs, err := queries.ListScreenedJobPostIDs(c.Request.Context())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		screened := make(map[uuid.UUID]bool, len(screenedIDs))
+		for _, id := range screenedIDs {
+			screened[id] = true
+		}
+		recentlyViewed, err := queries.ListRecentlyViewedJobPosts(c.Request.Context(), sqlc.ListRecentlyViewedJobPostsParams{
+			ViewerID: uuid.NullUUID{UUID: uid, Valid: true},
+			Limit:    5,
+		})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		profile, err := profileService.Get(c.Request.Context(), uid)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
 			"title":   "Applicant Dashboard",
 			"name":    userName,
 			"role":    "Applicant",
@@ -97,289 +270,1334 @@ func main() {
 				"resume":    "Upload Resume",
 				"interview": "Interview Requests",
 			},
-			"jobPosts": jobPosts,
+			"jobPosts":       jobPosts,
+			"applied":        applied,
+			"saved":          saved,
+			"screened":       screened,
+			"recentlyViewed": recentlyViewed,
+			"completeness":   profile.Completeness,
 		})
 	})
 
-	applicantRoutes.GET("/profile", func(c *gin.Context) {
+	applicantRoutes.POST("/job-posting/apply/:id", limiters.Apply.Middleware(), func(c *gin.Context) {
+		session := sessions.Default(c)
+		jobID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		if _, err := queries.GetVisibleJobPost(c.Request.Context(), jobID); err != nil {
+			if err == sql.ErrNoRows {
+				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Job post not found"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if err := c.Request.ParseForm(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		application, knockedOut, err := screeningService.Apply(c.Request.Context(), sqlc.CreateApplicationParams{
+			JobPostingID: jobID,
+			ApplicantID:  uid,
+			Source:       analytics.ViewSource(c.PostForm("src")),
+		}, c.Request.PostForm)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "You have already applied to this job"})
+				return
+			}
+			c.AbortWithStatusJSON(screeningErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		metrics.ApplicationsSubmitted.Inc()
+		if knockedOut {
+			metrics.ApplicationsKnockedOut.Inc()
+		}
+		recorder.Record(c, audit.ActionApplicationCreated, audit.TargetApplication, application.ID.String(), nil, gin.H{
+			"job_posting_id": jobID,
+			"source":         application.Source,
+			"stage":          application.Stage,
+		})
+		c.Redirect(http.StatusSeeOther, "/applicant/dashboard")
+	})
+
+	applicantRoutes.GET("/saved-jobs", func(c *gin.Context) {
 		session := sessions.Default(c)
 		userName := session.Get("name")
 		pictureURL := session.Get("picture").(string)
-		c.HTML(http.StatusOK, "dashboard.html", gin.H{
-			"title":   "Applicant Profile",
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		savedJobs, err := queries.ListSavedJobPosts(c.Request.Context(), uid)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Saved Jobs",
 			"name":    userName,
 			"role":    "Applicant",
-			"page":    "Profile",
+			"page":    "Saved Jobs",
 			"picture": pictureURL,
 			"applicant": gin.H{
 				"resume":    "Upload Resume",
 				"interview": "Interview Requests",
 			},
+			"savedJobs": savedJobs,
 		})
 	})
 
-	applicantRoutes.POST("/profile/update", func(c *gin.Context) {
+	applicantRoutes.POST("/saved-jobs/:id", func(c *gin.Context) {
 		session := sessions.Default(c)
-		skills := strings.Split(c.PostForm("skills"), ",")
-		for i := range skills {
-			skills[i] = strings.TrimSpace(skills[i])
+		jobID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
 		}
-		id := session.Get("id").(string)
-		uid, err := uuid.Parse(id)
+		uid, err := uuid.Parse(session.Get("id").(string))
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
 			return
 		}
-		queries.UpdateApplicantSkills(context.Background(), sqlc.UpdateApplicantSkillsParams{
-			ApplicantID: uid,
-			Skills:      skills,
-		})
-		c.Redirect(http.StatusSeeOther, "/applicant/dashboard")
+		if _, err := queries.GetVisibleJobPost(c.Request.Context(), jobID); err != nil {
+			if err == sql.ErrNoRows {
+				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Job post not found"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		err = queries.SaveJob(c.Request.Context(), sqlc.SaveJobParams{ApplicantID: uid, JobPostingID: jobID})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Redirect(http.StatusSeeOther, "/applicant/saved-jobs")
 	})
 
-	applicantRoutes.GET("/upload-resume", func(c *gin.Context) {
+	applicantRoutes.POST("/saved-jobs/:id/delete", func(c *gin.Context) {
 		session := sessions.Default(c)
-		userName := session.Get("name")
-		pictureURL := session.Get("picture").(string)
-		c.HTML(http.StatusOK, "dashboard.html", gin.H{
-			"title":   "Upload Resume",
-			"name":    userName,
-			"role":    "Applicant",
-			"page":    "Upload Resume",
-			"picture": pictureURL,
-			"applicant": gin.H{
-				"resume":    "Upload Resume",
-				"interview": "Interview Requests",
-			},
-		})
+		jobID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		err = queries.UnsaveJob(c.Request.Context(), sqlc.UnsaveJobParams{ApplicantID: uid, JobPostingID: jobID})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Redirect(http.StatusSeeOther, "/applicant/saved-jobs")
 	})
 
-	applicantRoutes.GET("/interview-requests", func(c *gin.Context) {
+	applicantRoutes.GET("/job-alerts", func(c *gin.Context) {
 		session := sessions.Default(c)
 		userName := session.Get("name")
 		pictureURL := session.Get("picture").(string)
-		c.HTML(http.StatusOK, "dashboard.html", gin.H{
-			"title":   "Interview Requests",
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		savedSearches, err := queries.ListSavedSearches(c.Request.Context(), uid)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		notifications, err := queries.ListNotifications(c.Request.Context(), sqlc.ListNotificationsParams{UserID: uid, Limit: 20})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		// Notifications listed here count as read from the next visit on,
+		// so this one still highlights what is new.
+		if err := queries.MarkNotificationsRead(c.Request.Context(), uid); err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Job Alerts",
 			"name":    userName,
 			"role":    "Applicant",
-			"page":    "Interview Requests",
+			"page":    "Job Alerts",
 			"picture": pictureURL,
 			"applicant": gin.H{
 				"resume":    "Upload Resume",
 				"interview": "Interview Requests",
 			},
+			"savedSearches": savedSearches,
+			"notifications": notifications,
+			"frequencies":   alerts.Frequencies,
+			"emailAlerts":   cfg.Mail.SMTPHost != "",
 		})
 	})
 
-	// recruiter routes
-
-	recruiterRoutes := r.Group("/recruiter")
-	recruiterRoutes.Use(middlewares.AuthMiddleware(), middlewares.RecruiterOnlyMiddleware())
-
-	r.GET("/recruiter/create-company", func(c *gin.Context) {
-		session := sessions.Default(c)
-		id := session.Get("id").(string)
-		c.HTML(http.StatusOK, "create_company.html", gin.H{
-			"ID":    id,
-			"Title": "Create Company",
-		})
-	})
-
-	r.POST("/recruiter/create-company/:id", func(c *gin.Context) {
+	applicantRoutes.POST("/saved-searches", func(c *gin.Context) {
 		session := sessions.Default(c)
-		logo := session.Get("logo")
-		name := c.PostForm("name")
-		description := c.PostForm("description")
-		company_id := uuid.New()
-		id := c.Param("id")
-		uid, err := uuid.Parse(id)
+		uid, err := uuid.Parse(session.Get("id").(string))
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
 			return
 		}
-		companyParams := sqlc.CreateCompanyParams{
-			ID:          company_id,
-			RecruiterID: uuid.NullUUID{UUID: uid, Valid: true},
-			Name:        name,
-			Description: sql.NullString{String: description, Valid: true},
-			Logo:        sql.NullString{String: logo.(string), Valid: true},
+		if err := c.Request.ParseForm(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
-		queries.CreateCompany(context.Background(), companyParams)
-		c.Redirect(http.StatusSeeOther, "/recruiter/pending")
+		params, err := alerts.ParseSearch(uid, c.Request.PostForm)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if params.Skills, err = skillsService.Canonical(c.Request.Context(), params.Skills); err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if _, err := queries.CreateSavedSearch(c.Request.Context(), params); err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Redirect(http.StatusSeeOther, "/applicant/job-alerts")
 	})
 
-	r.POST("/recruiter/create-company/:id/cancel", func(c *gin.Context) {
-		id := c.Param("id")
-		uid, err := uuid.Parse(id)
+	applicantRoutes.POST("/saved-searches/:id/delete", func(c *gin.Context) {
+		session := sessions.Default(c)
+		searchID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		uid, err := uuid.Parse(session.Get("id").(string))
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
 			return
 		}
-		err = queries.RejectRecruiter(context.Background(), uid)
+		deleted, err := queries.DeleteSavedSearch(c.Request.Context(), sqlc.DeleteSavedSearchParams{ID: searchID, ApplicantID: uid})
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		service.LogoutHandler(c)
-		c.Redirect(http.StatusSeeOther, "/")
-	})
-
-	r.GET("/recruiter/pending", middlewares.AuthMiddleware(), func(c *gin.Context) {
-		role, _ := c.Get("role")
-		if role.(string) != "pending" {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Pending recruiters only"})
+		if deleted == 0 {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Saved search not found"})
 			return
 		}
-		c.HTML(http.StatusOK, "pending_page.html", gin.H{
-			"Title": "Waiting for Admin Approval",
-		})
+		c.Redirect(http.StatusSeeOther, "/applicant/job-alerts")
 	})
 
-	recruiterRoutes.GET("/dashboard", func(c *gin.Context) {
+	applicantRoutes.GET("/profile", func(c *gin.Context) {
 		session := sessions.Default(c)
 		userName := session.Get("name")
 		pictureURL := session.Get("picture").(string)
-		jobPosts, err := queries.GetAllJobPosts(context.Background())
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		profile, err := profileService.Get(c.Request.Context(), uid)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		c.HTML(http.StatusOK, "dashboard.html", gin.H{
-			"title":   "Recruiter Dashboard",
+		var pendingDeletion *sqlc.AccountDeletionRequest
+		deletion, err := queries.GetPendingAccountDeletion(c.Request.Context(), uid)
+		if err == nil {
+			pendingDeletion = &deletion
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Applicant Profile",
 			"name":    userName,
-			"role":    "Recruiter",
+			"role":    "Applicant",
+			"page":    "Profile",
 			"picture": pictureURL,
-			"recruiter": gin.H{
-				"job":       "Job Posting",
-				"interview": "Interview Scheduling",
-				"resume":    "Resume Parsing",
+			"applicant": gin.H{
+				"resume":    "Upload Resume",
+				"interview": "Interview Requests",
 			},
-			"page":     "Dashboard",
-			"jobPosts": jobPosts,
+			"profile":            profile,
+			"workAuthorizations": profiles.WorkAuthorizations,
+			"remotePreferences":  profiles.RemotePreferences,
+			"proficiencies":      skills.Proficiencies,
+			"newSkillRows":       3,
+			"pendingDeletion":    pendingDeletion,
+			"deletionGraceDays":  cfg.AccountDeletionGraceDays,
 		})
 	})
 
-	recruiterRoutes.GET("/job-posting", func(c *gin.Context) {
+	applicantRoutes.POST("/profile", middlewares.RequirePermission(authz.ProfileWrite), func(c *gin.Context) {
 		session := sessions.Default(c)
-		userName := session.Get("name")
-		pictureURL := session.Get("picture").(string)
-		c.HTML(http.StatusOK, "dashboard.html", gin.H{
-			"title":   "Recruiter Dashboard",
-			"name":    userName,
-			"role":    "Recruiter",
-			"picture": pictureURL,
-			"recruiter": gin.H{
-				"job":       "Job Posting",
-				"interview": "Interview Scheduling",
-				"resume":    "Resume Parsing",
-			},
-			"page": "Job Posting",
-		})
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		if err := c.Request.ParseForm(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if _, err := profileService.Update(c.Request.Context(), uid, c.Request.PostForm); err != nil {
+			c.AbortWithStatusJSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionProfileUpdated, audit.TargetUser, uid.String(), nil, nil)
+		c.Redirect(http.StatusSeeOther, "/applicant/profile")
 	})
 
-	recruiterRoutes.POST("/job-posting/create", func(c *gin.Context) {
+	applicantRoutes.POST("/profile/experience", middlewares.RequirePermission(authz.ProfileWrite), func(c *gin.Context) {
 		session := sessions.Default(c)
-		company_name := c.PostForm("company_name")
-		position := c.PostForm("position")
-		description := c.PostForm("description")
-		salary := c.PostForm("salary")
-		skills := strings.Split(c.PostForm("skills"), ",")
-		for i := range skills {
-			skills[i] = strings.TrimSpace(skills[i])
-		}
-		jobID := uuid.New()
-		id := session.Get("id").(string)
-		uid, err := uuid.Parse(id)
+		uid, err := uuid.Parse(session.Get("id").(string))
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
 			return
 		}
-		company, err := queries.GetCompanyByRecruiterID(context.Background(), uuid.NullUUID{UUID: uid, Valid: true})
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if err := c.Request.ParseForm(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		jobPostParams := sqlc.CreateJobPostParams{
-			ID:          jobID,
-			RecruiterID: uuid.NullUUID{UUID: uid, Valid: true},
-			CompanyID:   uuid.NullUUID{UUID: company.ID, Valid: true},
-			CompanyName: company_name,
-			Position:    position,
-			Skills:      skills,
-			Description: sql.NullString{String: description, Valid: true},
-			Salary:      sql.NullString{String: salary, Valid: true},
+		if _, err := profileService.AddExperience(c.Request.Context(), uid, c.Request.PostForm); err != nil {
+			c.AbortWithStatusJSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+			return
 		}
-		err = queries.CreateJobPost(context.Background(), jobPostParams)
+		c.Redirect(http.StatusSeeOther, "/applicant/profile")
+	})
+
+	applicantRoutes.POST("/profile/experience/:id/delete", middlewares.RequirePermission(authz.ProfileWrite), func(c *gin.Context) {
+		session := sessions.Default(c)
+		entryID, err := uuid.Parse(c.Param("id"))
 		if err != nil {
-			log.Println("error:", err)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
 			return
 		}
-		c.Redirect(http.StatusSeeOther, "/recruiter/dashboard")
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		if err := profileService.RemoveExperience(c.Request.Context(), uid, entryID); err != nil {
+			c.AbortWithStatusJSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.Redirect(http.StatusSeeOther, "/applicant/profile")
 	})
 
-	recruiterRoutes.POST("/job-posting/delete/:id", func(c *gin.Context) {
-		id := c.Param("id")
-		uid, err := uuid.Parse(id)
+	applicantRoutes.POST("/profile/education", middlewares.RequirePermission(authz.ProfileWrite), func(c *gin.Context) {
+		session := sessions.Default(c)
+		uid, err := uuid.Parse(session.Get("id").(string))
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
 			return
 		}
-		err = queries.DeleteJobPost(context.Background(), uid)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if err := c.Request.ParseForm(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		c.Redirect(http.StatusSeeOther, "/recruiter/dashboard")
+		if _, err := profileService.AddEducation(c.Request.Context(), uid, c.Request.PostForm); err != nil {
+			c.AbortWithStatusJSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.Redirect(http.StatusSeeOther, "/applicant/profile")
 	})
 
-	recruiterRoutes.GET("/interview-scheduling", func(c *gin.Context) {
+	applicantRoutes.POST("/profile/education/:id/delete", middlewares.RequirePermission(authz.ProfileWrite), func(c *gin.Context) {
 		session := sessions.Default(c)
-		userName := session.Get("name")
+		entryID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		if err := profileService.RemoveEducation(c.Request.Context(), uid, entryID); err != nil {
+			c.AbortWithStatusJSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.Redirect(http.StatusSeeOther, "/applicant/profile")
+	})
+
+	applicantRoutes.GET("/privacy/export", middlewares.RequirePermission(authz.AccountManage), func(c *gin.Context) {
+		session := sessions.Default(c)
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		archive, err := privacyService.Export(c.Request.Context(), uid)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionDataExported, audit.TargetUser, uid.String(), nil, nil)
+		c.Header("Content-Disposition", `attachment; filename="my-data.zip"`)
+		c.Data(http.StatusOK, "application/zip", archive)
+	})
+
+	applicantRoutes.POST("/privacy/delete", middlewares.RequirePermission(authz.AccountManage), func(c *gin.Context) {
+		session := sessions.Default(c)
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		deletion, err := privacyService.RequestDeletion(c.Request.Context(), uid)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionDeletionRequested, audit.TargetUser, uid.String(), nil, gin.H{"scheduled_for": deletion.ScheduledFor})
+		c.Redirect(http.StatusSeeOther, "/applicant/profile")
+	})
+
+	applicantRoutes.POST("/privacy/delete/cancel", middlewares.RequirePermission(authz.AccountManage), func(c *gin.Context) {
+		session := sessions.Default(c)
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		err = queries.CancelAccountDeletion(c.Request.Context(), uid)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionDeletionCancelled, audit.TargetUser, uid.String(), nil, nil)
+		c.Redirect(http.StatusSeeOther, "/applicant/profile")
+	})
+
+	applicantRoutes.POST("/profile/update", middlewares.RequirePermission(authz.ProfileWrite), func(c *gin.Context) {
+		session := sessions.Default(c)
+		id := session.Get("id").(string)
+		uid, err := uuid.Parse(id)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		if err := c.Request.ParseForm(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		entries, err := skills.ParseApplicantSkills(c.Request.PostForm)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		names, err := skillsService.SetApplicantSkills(c.Request.Context(), uid, entries)
+		if err != nil {
+			c.AbortWithStatusJSON(skillsErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionSkillsUpdated, audit.TargetUser, uid.String(), nil, gin.H{"skills": names})
+		c.Redirect(http.StatusSeeOther, "/applicant/profile")
+	})
+
+	applicantRoutes.GET("/upload-resume", func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
+		pictureURL := session.Get("picture").(string)
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Upload Resume",
+			"name":    userName,
+			"role":    "Applicant",
+			"page":    "Upload Resume",
+			"picture": pictureURL,
+			"applicant": gin.H{
+				"resume":    "Upload Resume",
+				"interview": "Interview Requests",
+			},
+		})
+	})
+
+	applicantRoutes.GET("/interview-requests", func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
+		pictureURL := session.Get("picture").(string)
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Interview Requests",
+			"name":    userName,
+			"role":    "Applicant",
+			"page":    "Interview Requests",
+			"picture": pictureURL,
+			"applicant": gin.H{
+				"resume":    "Upload Resume",
+				"interview": "Interview Requests",
+			},
+		})
+	})
+
+	// job routes
+
+	jobRoutes := r.Group("/jobs")
+	jobRoutes.Use(middlewares.AuthMiddleware(service, permissions), middlewares.RequirePermission(authz.JobsRead))
+
+	jobRoutes.GET("/:id", func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
+		pictureURL := session.Get("picture").(string)
+		jobID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		uid, err := uuid.Parse(c.GetString("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		jobPost, err := queries.GetVisibleJobPost(c.Request.Context(), jobID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Job post not found"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		var company *sqlc.Company
+		if jobPost.CompanyID.Valid {
+			found, err := queries.GetCompanyByID(c.Request.Context(), jobPost.CompanyID.UUID)
+			if err == nil {
+				company = &found
+			} else if !errors.Is(err, sql.ErrNoRows) {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+		appliedIDs, err := queries.ListAppliedJobPostIDs(c.Request.Context(), uid)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		savedIDs, err := queries.ListSavedJobPostIDs(c.Request.Context(), uid)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		questions, err := screeningService.Questions(c.Request.Context(), jobID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		source := analytics.ViewSource(c.Query("src"))
+		// Only applicants' views count, so recruiters checking their own
+		// postings and admins do not skew the apply rate. Views are counted
+		// once per viewer per day, so reloading the page does not inflate
+		// the recruiter's numbers.
+		if c.GetString("role") == "applicant" {
+			err = queries.RecordJobPostingView(c.Request.Context(), sqlc.RecordJobPostingViewParams{
+				JobPostingID: jobID,
+				ViewerID:     uuid.NullUUID{UUID: uid, Valid: true},
+				Source:       source,
+			})
+			if err != nil {
+				logging.FromGin(c).Error("recording job posting view", "error", err)
+			}
+		}
+		role, menuKey, menu := dashboardMenu(c.GetString("role"))
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":        jobPost.Position,
+			"name":         userName,
+			"role":         role,
+			"picture":      pictureURL,
+			menuKey:        menu,
+			"page":         "Job Detail",
+			"jobPost":      jobPost,
+			"company":      company,
+			"applied":      slices.Contains(appliedIDs, jobID),
+			"saved":        slices.Contains(savedIDs, jobID),
+			"questions":    questions,
+			"answerPrefix": screening.FieldPrefix,
+			"source":       source,
+		})
+	})
+
+	// skill routes
+
+	skillRoutes := r.Group("/skills")
+	skillRoutes.Use(middlewares.AuthMiddleware(service, permissions), middlewares.RequirePermission(authz.JobsRead))
+
+	// Suggestions for the skill fields on the posting, profile and saved
+	// search forms, matched on skill names and aliases.
+	skillRoutes.GET("", func(c *gin.Context) {
+		found, err := skillsService.Search(c.Request.Context(), c.Query("q"), 10)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		out := make([]gin.H, 0, len(found))
+		for _, skill := range found {
+			out = append(out, gin.H{"name": skill.Name, "category": skill.Category})
+		}
+		c.JSON(http.StatusOK, gin.H{"skills": out})
+	})
+
+	// recruiter routes
+
+	recruiterRoutes := r.Group("/recruiter")
+	recruiterRoutes.Use(middlewares.AuthMiddleware(service, permissions), middlewares.RequirePermission(authz.JobsWrite))
+
+	// Onboarding routes for recruiters waiting for approval.
+	onboardingRoutes := r.Group("/recruiter")
+	onboardingRoutes.Use(middlewares.AuthMiddleware(service, permissions), middlewares.RequireStatus(authz.StatusPending))
+
+	onboardingRoutes.GET("/create-company", func(c *gin.Context) {
+		session := sessions.Default(c)
+		id := session.Get("id").(string)
+		views.HTML(c, http.StatusOK, "create_company.html", gin.H{
+			"ID":    id,
+			"Title": "Create Company",
+		})
+	})
+
+	onboardingRoutes.POST("/create-company/:id", func(c *gin.Context) {
+		session := sessions.Default(c)
+		logo, _ := session.Get("logo").(string)
+		id := c.Param("id")
+		if id != c.GetString("id") {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You can only create your own company"})
+			return
+		}
+		uid, err := uuid.Parse(id)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		company, err := accountsService.CreateCompany(c.Request.Context(), uid, c.PostForm("name"), c.PostForm("description"), logo)
+		if err != nil {
+			c.AbortWithStatusJSON(accountsErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionCompanyCreated, audit.TargetCompany, company.ID.String(), nil, audit.CompanySnapshot(company))
+		c.Redirect(http.StatusSeeOther, "/recruiter/pending")
+	})
+
+	onboardingRoutes.POST("/create-company/:id/cancel", func(c *gin.Context) {
+		id := c.Param("id")
+		if id != c.GetString("id") {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You can only cancel your own signup"})
+			return
+		}
+		uid, err := uuid.Parse(id)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		removed, err := accountsService.CancelSignup(c.Request.Context(), uid)
+		if err != nil {
+			c.AbortWithStatusJSON(accountsErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionRecruiterCancelled, audit.TargetUser, uid.String(), removedRecruiterSnapshot(removed), nil)
+		service.LogoutHandler(c)
+		c.Redirect(http.StatusSeeOther, "/")
+	})
+
+	onboardingRoutes.GET("/pending", func(c *gin.Context) {
+		views.HTML(c, http.StatusOK, "pending_page.html", gin.H{
+			"Title": "Waiting for Admin Approval",
+		})
+	})
+
+	recruiterRoutes.GET("/dashboard", func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
+		pictureURL := session.Get("picture").(string)
+		jobPosts, err := queries.GetAllJobPosts(c.Request.Context())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Recruiter Dashboard",
+			"name":    userName,
+			"role":    "Recruiter",
+			"picture": pictureURL,
+			"recruiter": gin.H{
+				"job":       "Job Posting",
+				"interview": "Interview Scheduling",
+				"resume":    "Resume Parsing",
+			},
+			"page":     "Dashboard",
+			"jobPosts": jobPosts,
+		})
+	})
+
+	recruiterRoutes.GET("/job-posting", func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
+		pictureURL := session.Get("picture").(string)
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Recruiter Dashboard",
+			"name":    userName,
+			"role":    "Recruiter",
+			"picture": pictureURL,
+			"recruiter": gin.H{
+				"job":       "Job Posting",
+				"interview": "Interview Scheduling",
+				"resume":    "Resume Parsing",
+			},
+			"page": "Job Posting",
+		})
+	})
+
+	recruiterRoutes.POST("/job-posting/create", func(c *gin.Context) {
+		session := sessions.Default(c)
+		company_name := c.PostForm("company_name")
+		position := c.PostForm("position")
+		description := c.PostForm("description")
+		salary := c.PostForm("salary")
+		jobID := uuid.New()
+		id := session.Get("id").(string)
+		uid, err := uuid.Parse(id)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		company, err := queries.GetCompanyByRecruiterID(c.Request.Context(), uuid.NullUUID{UUID: uid, Valid: true})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		jobPostParams, err := skillsService.CreateJobPost(c.Request.Context(), sqlc.CreateJobPostParams{
+			ID:              jobID,
+			RecruiterID:     uuid.NullUUID{UUID: uid, Valid: true},
+			CompanyID:       uuid.NullUUID{UUID: company.ID, Valid: true},
+			CompanyName:     company_name,
+			Position:        position,
+			Skills:          skills.Split(c.PostForm("skills")),
+			PreferredSkills: skills.Split(c.PostForm("preferred_skills")),
+			Description:     sql.NullString{String: description, Valid: true},
+			Salary:          sql.NullString{String: salary, Valid: true},
+		})
+		if err != nil {
+			logging.FromGin(c).Error("creating job post", "error", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		metrics.JobPostsCreated.Inc()
+		recorder.Record(c, audit.ActionJobPostCreated, audit.TargetJobPost, jobID.String(), nil, webhooks.JobPostedData(jobPostParams))
+		err = webhooksService.Publish(c.Request.Context(), company.ID, webhooks.EventJobPosted, webhooks.JobPostedData(jobPostParams))
+		if err != nil {
+			logging.FromGin(c).Error("publishing webhook", "event", webhooks.EventJobPosted, "error", err)
+		}
+		c.Redirect(http.StatusSeeOther, "/recruiter/dashboard")
+	})
+
+	// ownedJobPost loads the live posting named by the :id parameter if it
+	// belongs to the signed-in recruiter's company. Otherwise it aborts the
+	// request and reports false.
+	ownedJobPost := func(c *gin.Context) (sqlc.JobPosting, bool) {
+		uid, err := uuid.Parse(sessions.Default(c).Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return sqlc.JobPosting{}, false
+		}
+		return api.OwnedJobPost(c, queries, uid)
+	}
+
+	recruiterRoutes.POST("/job-posting/delete/:id", func(c *gin.Context) {
+		jobPost, ok := ownedJobPost(c)
+		if !ok {
+			return
+		}
+		err := queries.DeleteJobPost(c.Request.Context(), jobPost.ID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionJobPostDeleted, audit.TargetJobPost, jobPost.ID.String(), audit.JobPostSnapshot(jobPost), nil)
+		c.Redirect(http.StatusSeeOther, "/recruiter/dashboard")
+	})
+
+	recruiterRoutes.GET("/postings", func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
+		pictureURL := session.Get("picture").(string)
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		company, err := queries.GetCompanyByRecruiterID(c.Request.Context(), uuid.NullUUID{UUID: uid, Valid: true})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		postings, err := analyticsService.Postings(c.Request.Context(), company.ID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "My Postings",
+			"name":    userName,
+			"role":    "Recruiter",
+			"picture": pictureURL,
+			"recruiter": gin.H{
+				"job":       "Job Posting",
+				"interview": "Interview Scheduling",
+				"resume":    "Resume Parsing",
+			},
+			"page":     "My Postings",
+			"company":  company,
+			"postings": postings,
+		})
+	})
+
+	recruiterRoutes.GET("/postings/:id/applications", func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
+		pictureURL := session.Get("picture").(string)
+		jobPost, ok := ownedJobPost(c)
+		if !ok {
+			return
+		}
+		applications, err := screeningService.Applications(c.Request.Context(), jobPost.ID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Applications",
+			"name":    userName,
+			"role":    "Recruiter",
+			"picture": pictureURL,
+			"recruiter": gin.H{
+				"job":       "Job Posting",
+				"interview": "Interview Scheduling",
+				"resume":    "Resume Parsing",
+			},
+			"page":         "Applications",
+			"jobPost":      jobPost,
+			"applications": applications,
+			"stages":       analytics.Stages,
+		})
+	})
+
+	// publishHired tells the company's webhooks that an application to one
+	// of its postings was hired. The move has already happened, so failures
+	// are only logged.
+	publishHired := func(c *gin.Context, jobPost sqlc.JobPosting, application sqlc.Application) {
+		applicant, err := queries.GetUserByID(c.Request.Context(), application.ApplicantID)
+		if err == nil {
+			err = webhooksService.Publish(c.Request.Context(), jobPost.CompanyID.UUID, webhooks.EventCandidateHired, webhooks.CandidateHiredData(application, jobPost, applicant))
+		}
+		if err != nil {
+			logging.FromGin(c).Error("publishing webhook", "event", webhooks.EventCandidateHired, "error", err)
+		}
+	}
+
+	recruiterRoutes.POST("/postings/:id/applications/:applicationID/stage", func(c *gin.Context) {
+		jobPost, ok := ownedJobPost(c)
+		if !ok {
+			return
+		}
+		applicationID, err := uuid.Parse(c.Param("applicationID"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		before, after, err := screeningService.MoveStage(c.Request.Context(), jobPost.ID, applicationID, c.PostForm("stage"))
+		if err != nil {
+			c.AbortWithStatusJSON(screeningErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionApplicationStaged, audit.TargetApplication, applicationID.String(), audit.ApplicationSnapshot(before), audit.ApplicationSnapshot(after))
+		if after.Stage == "hired" {
+			publishHired(c, jobPost, after)
+		}
+		c.Redirect(http.StatusSeeOther, "/recruiter/postings/"+jobPost.ID.String()+"/applications")
+	})
+
+	recruiterRoutes.GET("/postings/:id/questions", func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
+		pictureURL := session.Get("picture").(string)
+		jobPost, ok := ownedJobPost(c)
+		if !ok {
+			return
+		}
+		questions, err := screeningService.Questions(c.Request.Context(), jobPost.ID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Screening Questions",
+			"name":    userName,
+			"role":    "Recruiter",
+			"picture": pictureURL,
+			"recruiter": gin.H{
+				"job":       "Job Posting",
+				"interview": "Interview Scheduling",
+				"resume":    "Resume Parsing",
+			},
+			"page":      "Screening Questions",
+			"jobPost":   jobPost,
+			"questions": questions,
+			"kinds":     screening.Kinds,
+		})
+	})
+
+	recruiterRoutes.POST("/postings/:id/questions", func(c *gin.Context) {
+		jobPost, ok := ownedJobPost(c)
+		if !ok {
+			return
+		}
+		if err := c.Request.ParseForm(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		params, err := screening.ParseQuestion(jobPost.ID, c.Request.PostForm)
+		if err != nil {
+			c.AbortWithStatusJSON(screeningErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		question, err := screeningService.AddQuestion(c.Request.Context(), params)
+		if err != nil {
+			c.AbortWithStatusJSON(screeningErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionQuestionAdded, audit.TargetJobPost, jobPost.ID.String(), nil, audit.QuestionSnapshot(question))
+		c.Redirect(http.StatusSeeOther, "/recruiter/postings/"+jobPost.ID.String()+"/questions")
+	})
+
+	recruiterRoutes.POST("/postings/:id/questions/:questionID/delete", func(c *gin.Context) {
+		jobPost, ok := ownedJobPost(c)
+		if !ok {
+			return
+		}
+		questionID, err := uuid.Parse(c.Param("questionID"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		if err := screeningService.RemoveQuestion(c.Request.Context(), jobPost.ID, questionID); err != nil {
+			c.AbortWithStatusJSON(screeningErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionQuestionRemoved, audit.TargetJobPost, jobPost.ID.String(), gin.H{"question_id": questionID}, nil)
+		c.Redirect(http.StatusSeeOther, "/recruiter/postings/"+jobPost.ID.String()+"/questions")
+	})
+
+	recruiterRoutes.GET("/interview-scheduling", func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
+		pictureURL := session.Get("picture").(string)
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Recruiter Dashboard",
+			"name":    userName,
+			"role":    "Recruiter",
+			"picture": pictureURL,
+			"recruiter": gin.H{
+				"job":       "Job Posting",
+				"interview": "Interview Scheduling",
+				"resume":    "Resume Parsing",
+			},
+			"page": "Interview Scheduling",
+		})
+	})
+
+	recruiterRoutes.GET("/resume-parsing", func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
+		pictureURL := session.Get("picture").(string)
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Recruiter Dashboard",
+			"name":    userName,
+			"role":    "Recruiter",
+			"picture": pictureURL,
+			"recruiter": gin.H{
+				"job":       "Job Posting",
+				"interview": "Interview Scheduling",
+				"resume":    "Resume Parsing",
+			},
+			"page": "Resume Parsing",
+		})
+	})
+
+	recruiterRoutes.GET("/webhooks", middlewares.RequirePermission(authz.WebhooksManage), func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
+		pictureURL := session.Get("picture").(string)
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		company, err := queries.GetCompanyByRecruiterID(c.Request.Context(), uuid.NullUUID{UUID: uid, Valid: true})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		subscriptions, err := queries.ListWebhookSubscriptions(c.Request.Context(), company.ID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Webhooks",
+			"name":    userName,
+			"role":    "Recruiter",
+			"picture": pictureURL,
+			"recruiter": gin.H{
+				"job":       "Job Posting",
+				"interview": "Interview Scheduling",
+				"resume":    "Resume Parsing",
+			},
+			"page":          "Webhooks",
+			"subscriptions": subscriptions,
+			"eventTypes":    webhooks.EventTypes,
+		})
+	})
+
+	recruiterRoutes.GET("/webhooks/:id", middlewares.RequirePermission(authz.WebhooksManage), func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
+		pictureURL := session.Get("picture").(string)
+		subscriptionID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		company, err := queries.GetCompanyByRecruiterID(c.Request.Context(), uuid.NullUUID{UUID: uid, Valid: true})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		subscription, err := queries.GetWebhookSubscription(c.Request.Context(), sqlc.GetWebhookSubscriptionParams{
+			ID:        subscriptionID,
+			CompanyID: company.ID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		deliveries, err := queries.ListWebhookDeliveries(c.Request.Context(), subscription.ID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Webhook Deliveries",
+			"name":    userName,
+			"role":    "Recruiter",
+			"picture": pictureURL,
+			"recruiter": gin.H{
+				"job":       "Job Posting",
+				"interview": "Interview Scheduling",
+				"resume":    "Resume Parsing",
+			},
+			"page":         "Webhook Deliveries",
+			"subscription": subscription,
+			"deliveries":   deliveries,
+		})
+	})
+
+	recruiterRoutes.POST("/webhooks/create", middlewares.RequirePermission(authz.WebhooksManage), func(c *gin.Context) {
+		session := sessions.Default(c)
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		company, err := queries.GetCompanyByRecruiterID(c.Request.Context(), uuid.NullUUID{UUID: uid, Valid: true})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		subscription, err := webhooksService.CreateSubscription(c.Request.Context(), company.ID, strings.TrimSpace(c.PostForm("url")), c.PostFormArray("event_types"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionWebhookCreated, audit.TargetWebhook, subscription.ID.String(), nil, gin.H{
+			"company_id":  company.ID,
+			"url":         subscription.Url,
+			"event_types": subscription.EventTypes,
+		})
+		c.Redirect(http.StatusSeeOther, "/recruiter/webhooks")
+	})
+
+	recruiterRoutes.POST("/webhooks/delete/:id", middlewares.RequirePermission(authz.WebhooksManage), func(c *gin.Context) {
+		session := sessions.Default(c)
+		subscriptionID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		company, err := queries.GetCompanyByRecruiterID(c.Request.Context(), uuid.NullUUID{UUID: uid, Valid: true})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		err = queries.DeleteWebhookSubscription(c.Request.Context(), sqlc.DeleteWebhookSubscriptionParams{
+			ID:        subscriptionID,
+			CompanyID: company.ID,
+		})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionWebhookDeleted, audit.TargetWebhook, subscriptionID.String(), nil, nil)
+		c.Redirect(http.StatusSeeOther, "/recruiter/webhooks")
+	})
+
+	recruiterRoutes.POST("/webhooks/enable/:id", middlewares.RequirePermission(authz.WebhooksManage), func(c *gin.Context) {
+		session := sessions.Default(c)
+		subscriptionID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		company, err := queries.GetCompanyByRecruiterID(c.Request.Context(), uuid.NullUUID{UUID: uid, Valid: true})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		err = queries.EnableWebhookSubscription(c.Request.Context(), sqlc.EnableWebhookSubscriptionParams{
+			ID:        subscriptionID,
+			CompanyID: company.ID,
+		})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionWebhookEnabled, audit.TargetWebhook, subscriptionID.String(), nil, nil)
+		c.Redirect(http.StatusSeeOther, "/recruiter/webhooks")
+	})
+
+	recruiterRoutes.POST("/webhooks/redeliver/:id", middlewares.RequirePermission(authz.WebhooksManage), func(c *gin.Context) {
+		session := sessions.Default(c)
+		deliveryID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		company, err := queries.GetCompanyByRecruiterID(c.Request.Context(), uuid.NullUUID{UUID: uid, Valid: true})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		err = queries.RedeliverWebhookDelivery(c.Request.Context(), sqlc.RedeliverWebhookDeliveryParams{
+			ID:        deliveryID,
+			CompanyID: company.ID,
+		})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionWebhookRedelivered, audit.TargetDelivery, deliveryID.String(), nil, nil)
+		redirect := "/recruiter/webhooks"
+		if subscriptionID, err := uuid.Parse(c.PostForm("subscription_id")); err == nil {
+			redirect += "/" + subscriptionID.String()
+		}
+		c.Redirect(http.StatusSeeOther, redirect)
+	})
+
+	// admin routes
+
+	adminRoutes := r.Group("/admin")
+	adminRoutes.Use(middlewares.AuthMiddleware(service, permissions), middlewares.RequirePermission(authz.UsersRead))
+
+	adminRoutes.GET("/dashboard", func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
 		pictureURL := session.Get("picture").(string)
-		c.HTML(http.StatusOK, "dashboard.html", gin.H{
-			"title":   "Recruiter Dashboard",
+		var overview *analytics.Overview
+		if middlewares.Permissions(c).Has(authz.AnalyticsRead) {
+			o, err := analyticsService.Overview(c.Request.Context())
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			overview = &o
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Admin Dashboard",
 			"name":    userName,
-			"role":    "Recruiter",
+			"role":    "Admin",
 			"picture": pictureURL,
-			"recruiter": gin.H{
-				"job":       "Job Posting",
-				"interview": "Interview Scheduling",
-				"resume":    "Resume Parsing",
+			"admin": gin.H{
+				"view": "View Users",
+				"add":  "Pending Recruiters",
 			},
-			"page": "Interview Scheduling",
+			"page":       "Dashboard",
+			"analytics":  overview,
+			"signupDays": analytics.SignupDays,
 		})
 	})
 
-	recruiterRoutes.GET("/resume-parsing", func(c *gin.Context) {
+	adminRoutes.GET("/view-users", func(c *gin.Context) {
 		session := sessions.Default(c)
 		userName := session.Get("name")
 		pictureURL := session.Get("picture").(string)
-		c.HTML(http.StatusOK, "dashboard.html", gin.H{
-			"title":   "Recruiter Dashboard",
+		filter := directory.ParseFilter(c.Request.URL.Query())
+		users, err := directoryService.Search(c.Request.Context(), filter)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		suspendedUsers, err := queries.ListSuspendedUsers(c.Request.Context())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "View Users",
 			"name":    userName,
-			"role":    "Recruiter",
+			"role":    "Admin",
 			"picture": pictureURL,
-			"recruiter": gin.H{
-				"job":       "Job Posting",
-				"interview": "Interview Scheduling",
-				"resume":    "Resume Parsing",
+			"admin": gin.H{
+				"view": "View Users",
+				"add":  "Pending Recruiters",
 			},
-			"page": "Resume Parsing",
+			"page":           "View Users",
+			"users":          users,
+			"filter":         filter,
+			"roles":          directory.Roles,
+			"statuses":       directory.Statuses,
+			"suspendedUsers": suspendedUsers,
 		})
 	})
 
-	// admin routes
+	adminRoutes.GET("/view-users/export.csv", func(c *gin.Context) {
+		filter := directory.ParseFilter(c.Request.URL.Query())
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="users-`+time.Now().Format("2006-01-02")+`.csv"`)
+		c.Status(http.StatusOK)
+		if err := directoryService.ExportCSV(c.Request.Context(), c.Writer, filter); err != nil {
+			// The status has already been sent, so the download just ends
+			// early; the log is the only place the failure shows.
+			logging.FromGin(c).Error("exporting users", "error", err)
+			c.Abort()
+			return
+		}
+		recorder.Record(c, audit.ActionUsersExported, audit.TargetSystem, "users", nil, filter.Query())
+	})
 
-	adminRoutes := r.Group("/admin")
-	adminRoutes.Use(middlewares.AuthMiddleware(), middlewares.AdminOnlyMiddleware())
+	adminRoutes.GET("/users/:id", func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
+		pictureURL := session.Get("picture").(string)
+		uid, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		detail, err := directoryService.Detail(c.Request.Context(), uid)
+		if err != nil {
+			if errors.Is(err, directory.ErrUserNotFound) {
+				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   detail.User.Name,
+			"name":    userName,
+			"role":    "Admin",
+			"picture": pictureURL,
+			"admin": gin.H{
+				"view": "View Users",
+				"add":  "Pending Recruiters",
+			},
+			"page":   "User Detail",
+			"detail": detail,
+		})
+	})
 
-	adminRoutes.GET("/dashboard", func(c *gin.Context) {
+	adminRoutes.POST("/suspend/:id", middlewares.RequirePermission(authz.UsersSuspend), func(c *gin.Context) {
+		uid, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		days, err := strconv.Atoi(c.DefaultPostForm("days", "0"))
+		if err != nil || days < 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid suspension length"})
+			return
+		}
+		var until time.Time
+		if days > 0 {
+			until = time.Now().Add(time.Duration(days) * 24 * time.Hour)
+		}
+		before, after, err := accountsService.Suspend(c.Request.Context(), uid, c.PostForm("reason"), until)
+		if err != nil {
+			c.AbortWithStatusJSON(accountsErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionUserSuspended, audit.TargetUser, uid.String(), audit.UserSnapshot(before), audit.UserSnapshot(after))
+		c.Redirect(http.StatusSeeOther, "/admin/view-users")
+	})
+
+	adminRoutes.POST("/unsuspend/:id", middlewares.RequirePermission(authz.UsersSuspend), func(c *gin.Context) {
+		uid, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		before, after, err := accountsService.Unsuspend(c.Request.Context(), uid)
+		if err != nil {
+			c.AbortWithStatusJSON(accountsErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionUserUnsuspended, audit.TargetUser, uid.String(), audit.UserSnapshot(before), audit.UserSnapshot(after))
+		c.Redirect(http.StatusSeeOther, "/admin/view-users")
+	})
+
+	adminRoutes.GET("/pending-recruiters", middlewares.RequirePermission(authz.RecruitersApprove), func(c *gin.Context) {
 		session := sessions.Default(c)
 		userName := session.Get("name")
 		pictureURL := session.Get("picture").(string)
-		c.HTML(http.StatusOK, "dashboard.html", gin.H{
-			"title":   "Admin Dashboard",
+		pendingRecruiters, err := queries.GetPendingRecruiters(c.Request.Context())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Pending Recruiters",
 			"name":    userName,
 			"role":    "Admin",
 			"picture": pictureURL,
@@ -387,21 +1605,72 @@ func main() {
 				"view": "View Users",
 				"add":  "Pending Recruiters",
 			},
-			"page": "Dashboard",
+			"page":              "Pending Recruiters",
+			"pendingRecruiters": pendingRecruiters,
 		})
 	})
 
-	adminRoutes.GET("/view-users", func(c *gin.Context) {
+	adminRoutes.POST("/approve-recruiter/:id", middlewares.RequirePermission(authz.RecruitersApprove), func(c *gin.Context) {
+		id := c.Param("id")
+		uid, err := uuid.Parse(id)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		before, after, err := accountsService.ApproveRecruiter(c.Request.Context(), uid)
+		if err != nil {
+			c.AbortWithStatusJSON(accountsErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionRecruiterApproved, audit.TargetUser, uid.String(), audit.UserSnapshot(before), audit.UserSnapshot(after))
+		c.Redirect(http.StatusSeeOther, "/admin/pending-recruiters")
+	})
+
+	adminRoutes.POST("/reject-recruiter/:id", middlewares.RequirePermission(authz.RecruitersApprove), func(c *gin.Context) {
+		id := c.Param("id")
+		uid, err := uuid.Parse(id)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		removed, err := accountsService.RejectRecruiter(c.Request.Context(), uid)
+		if err != nil {
+			c.AbortWithStatusJSON(accountsErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionRecruiterRejected, audit.TargetUser, uid.String(), removedRecruiterSnapshot(removed), nil)
+		c.Redirect(http.StatusSeeOther, "/admin/pending-recruiters")
+	})
+
+	adminRoutes.GET("/audit-log", middlewares.RequirePermission(authz.AuditRead), func(c *gin.Context) {
 		session := sessions.Default(c)
 		userName := session.Get("name")
 		pictureURL := session.Get("picture").(string)
-		users, err := queries.GetAllUsers(context.Background())
+		actor := strings.TrimSpace(c.Query("actor"))
+		action := c.Query("action")
+		params := sqlc.SearchAuditEventsParams{
+			Actor:    sql.NullString{String: actor, Valid: actor != ""},
+			Action:   sql.NullString{String: action, Valid: action != ""},
+			RowLimit: 200,
+		}
+		if from, err := time.Parse("2006-01-02", c.Query("from")); err == nil {
+			params.Since = sql.NullTime{Time: from, Valid: true}
+		}
+		if to, err := time.Parse("2006-01-02", c.Query("to")); err == nil {
+			params.Until = sql.NullTime{Time: to.AddDate(0, 0, 1), Valid: true}
+		}
+		events, err := queries.SearchAuditEvents(c.Request.Context(), params)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		c.HTML(http.StatusOK, "dashboard.html", gin.H{
-			"title":   "View Users",
+		actions, err := queries.ListAuditActions(c.Request.Context())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Audit Log",
 			"name":    userName,
 			"role":    "Admin",
 			"picture": pictureURL,
@@ -409,22 +1678,54 @@ func main() {
 				"view": "View Users",
 				"add":  "Pending Recruiters",
 			},
-			"page":  "View Users",
-			"users": users,
+			"page":    "Audit Log",
+			"events":  events,
+			"actions": actions,
+			"filter": gin.H{
+				"actor":  actor,
+				"action": action,
+				"from":   c.Query("from"),
+				"to":     c.Query("to"),
+			},
+		})
+	})
+
+	adminRoutes.GET("/metrics/db", middlewares.RequirePermission(authz.MetricsRead), func(c *gin.Context) {
+		stats := DB.Stats()
+		c.JSON(http.StatusOK, gin.H{
+			"max_open_connections": stats.MaxOpenConnections,
+			"open_connections":     stats.OpenConnections,
+			"in_use":               stats.InUse,
+			"idle":                 stats.Idle,
+			"wait_count":           stats.WaitCount,
+			"wait_duration_ms":     stats.WaitDuration.Milliseconds(),
+			"max_idle_closed":      stats.MaxIdleClosed,
+			"max_idle_time_closed": stats.MaxIdleTimeClosed,
+			"max_lifetime_closed":  stats.MaxLifetimeClosed,
 		})
 	})
 
-	adminRoutes.GET("/pending-recruiters", func(c *gin.Context) {
+	adminRoutes.GET("/deleted", func(c *gin.Context) {
 		session := sessions.Default(c)
 		userName := session.Get("name")
 		pictureURL := session.Get("picture").(string)
-		pendingRecruiters, err := queries.GetPendingRecruiters(context.Background())
+		deletedUsers, err := queries.ListDeletedUsers(c.Request.Context())
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		c.HTML(http.StatusOK, "dashboard.html", gin.H{
-			"title":   "Pending Recruiters",
+		deletedCompanies, err := queries.ListDeletedCompanies(c.Request.Context())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		deletedJobPosts, err := queries.ListDeletedJobPosts(c.Request.Context())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":   "Deleted Items",
 			"name":    userName,
 			"role":    "Admin",
 			"picture": pictureURL,
@@ -432,45 +1733,368 @@ func main() {
 				"view": "View Users",
 				"add":  "Pending Recruiters",
 			},
-			"page":              "Pending Recruiters",
-			"pendingRecruiters": pendingRecruiters,
+			"page":             "Deleted Items",
+			"deletedUsers":     deletedUsers,
+			"deletedCompanies": deletedCompanies,
+			"deletedJobPosts":  deletedJobPosts,
+			"retentionDays":    cfg.SoftDeleteRetentionDays,
 		})
 	})
 
-	adminRoutes.POST("/approve-recruiter/:id", func(c *gin.Context) {
+	adminRoutes.POST("/restore/user/:id", middlewares.RequirePermission(authz.UsersRestore), func(c *gin.Context) {
 		id := c.Param("id")
 		uid, err := uuid.Parse(id)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
 			return
 		}
-		err = queries.ApproveRecruiter(context.Background(), uid)
+		err = queries.RestoreUser(c.Request.Context(), uid)
 		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "Another active account already uses this email"})
+				return
+			}
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		c.Redirect(http.StatusSeeOther, "/admin/pending-recruiters")
+		recorder.Record(c, audit.ActionUserRestored, audit.TargetUser, uid.String(), nil, nil)
+		c.Redirect(http.StatusSeeOther, "/admin/deleted")
 	})
 
-	adminRoutes.POST("/reject-recruiter/:id", func(c *gin.Context) {
+	adminRoutes.POST("/restore/company/:id", middlewares.RequirePermission(authz.UsersRestore), func(c *gin.Context) {
 		id := c.Param("id")
 		uid, err := uuid.Parse(id)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
 			return
 		}
-		err = queries.RejectRecruiter(context.Background(), uid)
+		err = queries.RestoreCompany(c.Request.Context(), uid)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		err = queries.RejectCompany(context.Background(), uuid.NullUUID{UUID: uid, Valid: true})
+		recorder.Record(c, audit.ActionCompanyRestored, audit.TargetCompany, uid.String(), nil, nil)
+		c.Redirect(http.StatusSeeOther, "/admin/deleted")
+	})
+
+	adminRoutes.POST("/restore/job-post/:id", middlewares.RequirePermission(authz.UsersRestore), func(c *gin.Context) {
+		id := c.Param("id")
+		uid, err := uuid.Parse(id)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		err = queries.RestoreJobPost(c.Request.Context(), uid)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		c.Redirect(http.StatusSeeOther, "/admin/pending-recruiters")
+		recorder.Record(c, audit.ActionJobPostRestored, audit.TargetJobPost, uid.String(), nil, nil)
+		c.Redirect(http.StatusSeeOther, "/admin/deleted")
+	})
+
+	// settings routes
+
+	settingsRoutes := r.Group("/settings")
+	settingsRoutes.Use(middlewares.AuthMiddleware(service, permissions), middlewares.RequirePermission(authz.TokensManage))
+
+	settingsRoutes.GET("/tokens", func(c *gin.Context) {
+		session := sessions.Default(c)
+		userName := session.Get("name")
+		pictureURL := session.Get("picture").(string)
+		id := session.Get("id").(string)
+		uid, err := uuid.Parse(id)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		tokens, err := queries.ListPersonalAccessTokens(c.Request.Context(), uid)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		newToken := session.Flashes("new_token")
+		session.Save()
+		role, menuKey, menu := dashboardMenu(session.Get("role").(string))
+		views.HTML(c, http.StatusOK, "dashboard.html", gin.H{
+			"title":    "Access Tokens",
+			"name":     userName,
+			"role":     role,
+			"picture":  pictureURL,
+			menuKey:    menu,
+			"page":     "Access Tokens",
+			"tokens":   tokens,
+			"scopes":   grantableScopes(middlewares.Permissions(c)),
+			"newToken": newToken,
+		})
+	})
+
+	settingsRoutes.POST("/tokens/create", func(c *gin.Context) {
+		session := sessions.Default(c)
+		name := strings.TrimSpace(c.PostForm("name"))
+		if name == "" {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Token name is required"})
+			return
+		}
+		days, err := strconv.Atoi(c.DefaultPostForm("expires_in_days", "0"))
+		if err != nil || days < 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid expiry"})
+			return
+		}
+		id := session.Get("id").(string)
+		uid, err := uuid.Parse(id)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		scopes := c.PostFormArray("scopes")
+		for _, scope := range scopes {
+			if !middlewares.Permissions(c).Has(scope) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Your role does not grant " + scope})
+				return
+			}
+		}
+		token, record, err := service.CreateToken(c.Request.Context(), uid, name, scopes, time.Duration(days)*24*time.Hour)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionTokenCreated, audit.TargetToken, record.ID.String(), nil, gin.H{
+			"name":            name,
+			"scopes":          scopes,
+			"expires_in_days": days,
+		})
+		session.AddFlash(token, "new_token")
+		session.Save()
+		c.Redirect(http.StatusSeeOther, "/settings/tokens")
+	})
+
+	settingsRoutes.POST("/tokens/revoke/:id", func(c *gin.Context) {
+		session := sessions.Default(c)
+		tokenID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		uid, err := uuid.Parse(session.Get("id").(string))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+			return
+		}
+		err = queries.DeletePersonalAccessToken(c.Request.Context(), sqlc.DeletePersonalAccessTokenParams{
+			ID:     tokenID,
+			UserID: uid,
+		})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		recorder.Record(c, audit.ActionTokenRevoked, audit.TargetToken, tokenID.String(), nil, nil)
+		c.Redirect(http.StatusSeeOther, "/settings/tokens")
 	})
 
-	r.Run(":8080")
+	// api routes
+
+	apiRoutes := r.Group("/api")
+	apiRoutes.Use(middlewares.TokenAuthMiddleware(service, permissions))
+
+	apiRoutes.GET("/me", middlewares.RequirePermission(authz.ProfileRead), apiService.MeHandler)
+	apiRoutes.GET("/job-posts", middlewares.RequirePermission(authz.JobsRead), apiService.ListJobPostsHandler)
+	apiRoutes.POST("/job-posts", middlewares.RequirePermission(authz.JobsWrite), apiService.CreateJobPostHandler)
+	apiRoutes.DELETE("/job-posts/:id", middlewares.RequirePermission(authz.JobsWrite), apiService.DeleteJobPostHandler)
+	apiRoutes.PUT("/profile/skills", middlewares.RequirePermission(authz.ProfileWrite), apiService.UpdateSkillsHandler)
+
+	signalCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Addr:         cfg.Addr(),
+		Handler:      r,
+		ReadTimeout:  cfg.HTTP.ReadTimeout,
+		WriteTimeout: cfg.HTTP.WriteTimeout,
+		IdleTimeout:  cfg.HTTP.IdleTimeout,
+	}
+	go func() {
+		slog.Info("listening", "addr", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal("serving HTTP", err)
+		}
+	}()
+
+	var metricsSrv *http.Server
+	if cfg.Metrics.Addr != "" {
+		mr := gin.New()
+		mr.Use(gin.Recovery())
+		mr.GET("/metrics", metrics.Handler(cfg.Metrics.Token))
+		metricsSrv = &http.Server{
+			Addr:         cfg.Metrics.Addr,
+			Handler:      mr,
+			ReadTimeout:  cfg.HTTP.ReadTimeout,
+			WriteTimeout: cfg.HTTP.WriteTimeout,
+			IdleTimeout:  cfg.HTTP.IdleTimeout,
+		}
+		go func() {
+			if err := metricsSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				fatal("serving metrics", err)
+			}
+		}()
+	}
+
+	<-signalCtx.Done()
+	stop()
+	slog.Info("shutting down")
+	checker.SetShuttingDown()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("draining HTTP server", "error", err)
+	}
+	if metricsSrv != nil {
+		if err := metricsSrv.Shutdown(shutdownCtx); err != nil {
+			slog.Error("draining metrics server", "error", err)
+		}
+	}
+
+	stopWorkers()
+	done := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-shutdownCtx.Done():
+		slog.Warn("timed out waiting for background workers")
+	}
+
+	if err := DB.Close(); err != nil {
+		slog.Error("closing database", "error", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("flushing traces", "error", err)
+	}
+}
+
+// dashboardMenu returns the role label, the template key and the sidebar
+// entries dashboard.html expects for a session role.
+func dashboardMenu(role string) (string, string, gin.H) {
+	switch role {
+	case "admin":
+		return "Admin", "admin", gin.H{
+			"view": "View Users",
+			"add":  "Pending Recruiters",
+		}
+	case "recruiter":
+		return "Recruiter", "recruiter", gin.H{
+			"job":       "Job Posting",
+			"interview": "Interview Scheduling",
+			"resume":    "Resume Parsing",
+		}
+	default:
+		return "Applicant", "applicant", gin.H{
+			"resume":    "Upload Resume",
+			"interview": "Interview Requests",
+		}
+	}
+}
+
+// grantableScopes returns the token scopes a user with perms may grant.
+func grantableScopes(perms authz.Set) []string {
+	var scopes []string
+	for _, scope := range auth.TokenScopes {
+		if perms.Has(scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// accountsErrorStatus maps accounts service errors to HTTP statuses.
+func accountsErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, accounts.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, accounts.ErrNotPending), errors.Is(err, accounts.ErrCompanyExists),
+		errors.Is(err, accounts.ErrNotSuspendable), errors.Is(err, accounts.ErrNotSuspended):
+		return http.StatusConflict
+	case errors.Is(err, accounts.ErrCompanyNameRequired), errors.Is(err, accounts.ErrReasonRequired):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// profileErrorStatus maps profiles service errors to HTTP statuses.
+func profileErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, profiles.ErrEntryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, profiles.ErrInvalidChoice), errors.Is(err, profiles.ErrInvalidYears),
+		errors.Is(err, profiles.ErrInvalidSalary), errors.Is(err, profiles.ErrInvalidURL),
+		errors.Is(err, profiles.ErrMissingField), errors.Is(err, profiles.ErrInvalidDate),
+		errors.Is(err, profiles.ErrDatesOutOfOrder):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func screeningErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, screening.ErrQuestionNotFound), errors.Is(err, screening.ErrApplicationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, screening.ErrMissingPrompt), errors.Is(err, screening.ErrInvalidKind),
+		errors.Is(err, screening.ErrMissingOptions), errors.Is(err, screening.ErrInvalidKnockout),
+		errors.Is(err, screening.ErrAnswerRequired), errors.Is(err, screening.ErrInvalidAnswer),
+		errors.Is(err, screening.ErrInvalidStage):
+		return http.StatusBadRequest
+	case errors.Is(err, screening.ErrStageUnchanged), errors.Is(err, screening.ErrApplicationHired):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func skillsErrorStatus(err error) int {
+	if errors.Is(err, skills.ErrInvalidProficiency) || errors.Is(err, skills.ErrInvalidYears) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// removedRecruiterSnapshot is the audit "before" of a rejected or cancelled
+// recruiter signup.
+func removedRecruiterSnapshot(removed accounts.RemovedRecruiter) gin.H {
+	before := gin.H{"user": audit.UserSnapshot(removed.User)}
+	if removed.Company != nil {
+		before["company"] = audit.CompanySnapshot(*removed.Company)
+	}
+	return before
+}
+
+// fatal logs err and exits. It is only used during startup and for errors
+// that leave the server unable to run.
+// migrate runs a migrate subcommand. It needs only the database settings,
+// so it works before sessions and OAuth are configured, and it does not
+// set up tracing.
+func migrate(args []string) {
+	cfg, err := config.LoadDatabase()
+	if err != nil {
+		fatal("loading configuration", err)
+	}
+	DB, err := db.NewDB(cfg)
+	if err != nil {
+		fatal("connecting to database", err)
+	}
+	err = db.RunMigrateCommand(DB, args)
+	DB.Close()
+	if err != nil {
+		fatal("running migration", err)
+	}
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
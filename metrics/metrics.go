@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by method and route template.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Database query latency by sqlc query name.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"query"})
+
+	ApplicationsSubmitted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "applications_submitted_total",
+		Help: "Job applications submitted by applicants.",
+	})
+
+	ApplicationsKnockedOut = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "applications_knocked_out_total",
+		Help: "Applications rejected automatically by a screening question's knockout rule.",
+	})
+
+	RecruitersApproved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "recruiters_approved_total",
+		Help: "Pending recruiters approved by an admin.",
+	})
+
+	JobPostsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "job_posts_created_total",
+		Help: "Job postings created, from the dashboard or the API.",
+	})
+
+	JobAlertsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "job_alerts_sent_total",
+		Help: "Job alert digests sent to applicants, by saved search frequency.",
+	}, []string{"frequency"})
+
+	RateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rate_limited_requests_total",
+		Help: "Requests rejected with 429 by rate limiter.",
+	}, []string{"limiter"})
+)
+
+// unmatchedRoute labels requests that hit no route, so probing random URLs
+// cannot create new series.
+const unmatchedRoute = "unmatched"
+
+// Middleware records request counts and latency labelled by the route
+// template (e.g. /admin/approve-recruiter/:id) rather than the raw path, so
+// IDs in URLs do not blow up label cardinality.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+		httpRequests.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		httpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler serves the default registry in the Prometheus text format. With a
+// token set, scrapes must send it as "Authorization: Bearer <token>".
+func Handler(token string) gin.HandlerFunc {
+	h := promhttp.Handler()
+	return func(c *gin.Context) {
+		if token != "" {
+			got, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				c.Header("WWW-Authenticate", `Bearer realm="metrics"`)
+				c.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+		}
+		h.ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+// RegisterDB exposes the connection pool stats of conn and the number of
+// stored sessions, both read at scrape time.
+func RegisterDB(conn *sql.DB, countSessions func(context.Context) (int64, error)) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(conn, "postgres"))
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "sessions_active",
+		Help: "Signed-in sessions stored in the database.",
+	}, func() float64 {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		count, err := countSessions(ctx)
+		if err != nil {
+			slog.Error("counting sessions", "error", err)
+			return 0
+		}
+		return float64(count)
+	})
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHandlerToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const token = "0123456789abcdef"
+
+	tests := []struct {
+		name   string
+		token  string
+		header string
+		want   int
+	}{
+		{"no token configured", "", "", http.StatusOK},
+		{"correct token", token, "Bearer " + token, http.StatusOK},
+		{"missing header", token, "", http.StatusUnauthorized},
+		{"wrong token", token, "Bearer fedcba9876543210", http.StatusUnauthorized},
+		{"token prefix", token, "Bearer 0123", http.StatusUnauthorized},
+		{"not a bearer token", token, "Basic " + token, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		r := gin.New()
+		r.GET("/metrics", Handler(tt.token))
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if tt.header != "" {
+			req.Header.Set("Authorization", tt.header)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, w.Code, tt.want)
+		}
+	}
+}
@@ -1,47 +1,116 @@
 package middlewares
 
 import (
+	"errors"
+	"gin-app/auth"
+	"gin-app/authz"
 	"net/http"
+	"slices"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+const permissionsKey = "permissions"
+
+// AuthMiddleware loads the signed-in user from the cookie session and sets
+// the "id", "role" and "status" context keys, plus the permissions the user
+// holds, which RequirePermission checks. Role and status are read from the
+// database rather than the cookie, so approvals and suspensions apply to
+// sessions already signed in. A revoked session is cleared and the request
+// continues anonymously.
+func AuthMiddleware(service *auth.Service, store *authz.Store) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		session := sessions.Default(c)
-		role := session.Get("role")
-		c.Set("role", role)
+		if !loadSession(c, service, store) {
+			return
+		}
 		c.Next()
 	}
 }
 
-func ApplicantOnlyMiddleware() gin.HandlerFunc {
+// RequirePermission rejects requests from users lacking any of perms. Token
+// requests also need the token to have been granted each of them as a scope.
+func RequirePermission(perms ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if role, exists := c.Get("role"); !exists || role.(string) != "applicant" {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Applicants only"})
+		if c.GetString("id") == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Signed in users only"})
 			return
 		}
+		held := Permissions(c)
+		scopes, isToken := c.Get("scopes")
+		for _, p := range perms {
+			if !held.Has(p) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Missing permission " + p})
+				return
+			}
+			if isToken && !slices.Contains(scopes.([]string), p) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Token lacks scope " + p})
+				return
+			}
+		}
 		c.Next()
 	}
 }
 
-func RecruiterOnlyMiddleware() gin.HandlerFunc {
+// RequireStatus rejects requests from users whose account is not in status,
+// such as onboarding pages that only pending recruiters may see.
+func RequireStatus(status string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if role, exists := c.Get("role"); !exists || role.(string) != "recruiter" {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Recruiters only"})
+		if c.GetString("id") == "" || c.GetString("status") != status {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Not available for this account"})
 			return
 		}
 		c.Next()
 	}
 }
 
-func AdminOnlyMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		if role, exists := c.Get("role"); !exists || role.(string) != "admin" {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admins only"})
-			return
+// Permissions returns the permissions of the signed-in user, or an empty set.
+func Permissions(c *gin.Context) authz.Set {
+	perms, _ := c.Get(permissionsKey)
+	set, _ := perms.(authz.Set)
+	return set
+}
+
+// loadSession sets the context keys for the cookie session's user, or empty
+// ones if there is none. It aborts with 500 and returns false if the session
+// cannot be checked.
+func loadSession(c *gin.Context, service *auth.Service, store *authz.Store) bool {
+	var id, role, status string
+	session := sessions.Default(c)
+	if token, _ := session.Get("token").(string); token != "" {
+		user, err := service.SessionUser(c.Request.Context(), token)
+		switch {
+		case err == nil:
+			id, role, status = user.ID.String(), user.Role, user.Status
+		case errors.Is(err, auth.ErrSessionRevoked):
+			session.Clear()
+			if err := session.Save(); err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return false
+			}
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return false
+		}
+	}
+	c.Set("id", id)
+	c.Set("role", role)
+	c.Set("status", status)
+	return setPermissions(c, store, role, status)
+}
+
+// setPermissions stores the permissions of role, which only active accounts
+// hold. It aborts with 500 and returns false if they cannot be loaded.
+func setPermissions(c *gin.Context, store *authz.Store, role, status string) bool {
+	perms := authz.Set{}
+	if role != "" && status == authz.StatusActive {
+		var err error
+		perms, err = store.Permissions(c.Request.Context(), role)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return false
 		}
-		c.Next()
 	}
+	c.Set(permissionsKey, perms)
+	return true
 }
@@ -0,0 +1,117 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"gin-app/config"
+	db "gin-app/db/sqlc"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is one notification to a user. Body is plain text; Link is the
+// app path the notification points at.
+type Message struct {
+	UserID  uuid.UUID
+	Email   string
+	Kind    string
+	Subject string
+	Body    string
+	Link    string
+}
+
+// Notifier delivers messages over one channel.
+type Notifier interface {
+	Notify(ctx context.Context, m Message) error
+}
+
+// Store keeps notifications in the database, where the dashboard lists them.
+type Store struct {
+	Queries *db.Queries
+}
+
+func NewStore(queries *db.Queries) *Store {
+	return &Store{Queries: queries}
+}
+
+func (s *Store) Notify(ctx context.Context, m Message) error {
+	return s.Queries.CreateNotification(ctx, db.CreateNotificationParams{
+		UserID:  m.UserID,
+		Kind:    m.Kind,
+		Subject: m.Subject,
+		Body:    m.Body,
+		Link:    sql.NullString{String: m.Link, Valid: m.Link != ""},
+	})
+}
+
+// Mailer emails notifications through an SMTP server, authenticating only
+// if a username is configured.
+type Mailer struct {
+	Addr    string
+	Auth    smtp.Auth
+	From    string
+	BaseURL string
+}
+
+func NewMailer(cfg config.MailConfig) *Mailer {
+	m := &Mailer{
+		Addr:    net.JoinHostPort(cfg.SMTPHost, cfg.SMTPPort),
+		From:    cfg.From,
+		BaseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+	}
+	if cfg.Username != "" {
+		m.Auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.SMTPHost)
+	}
+	return m
+}
+
+func (m *Mailer) Notify(ctx context.Context, msg Message) error {
+	if msg.Email == "" {
+		return nil
+	}
+	body := msg.Body
+	if msg.Link != "" {
+		body += "\n\n" + m.BaseURL + msg.Link + "\n"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.Email)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", headerValue(msg.Subject)))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	// net/smtp takes no context, so a cancelled ctx only stops sending
+	// before the connection is made.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return smtp.SendMail(m.Addr, m.Auth, m.From, []string{msg.Email}, []byte(b.String()))
+}
+
+// Multi sends every message through each of its notifiers, so one failing
+// channel does not stop the others.
+type Multi []Notifier
+
+func (n Multi) Notify(ctx context.Context, m Message) error {
+	var errs []error
+	for _, notifier := range n {
+		if err := notifier.Notify(ctx, m); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// headerValue keeps user-supplied text from starting a new header.
+func headerValue(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
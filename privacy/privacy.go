@@ -0,0 +1,357 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"gin-app/db"
+	sqlc "gin-app/db/sqlc"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UploadsDir is where files uploaded by a user are stored, one directory per
+// user ID. Everything in a user's directory is included in their export and
+// removed when their account is anonymized.
+var UploadsDir = "uploads"
+
+type Service struct {
+	DB          db.TxBeginner
+	Queries     *sqlc.Queries
+	GracePeriod time.Duration
+}
+
+func NewService(conn db.TxBeginner, queries *sqlc.Queries, gracePeriod time.Duration) *Service {
+	return &Service{DB: conn, Queries: queries, GracePeriod: gracePeriod}
+}
+
+// Export builds a ZIP archive of everything stored about the user: one JSON
+// file per kind of record plus their uploaded files under files/.
+func (s *Service) Export(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	user, err := s.Queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := s.Queries.ListApplicantSkills(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.Queries.GetApplicantProfile(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	experience, err := s.Queries.ListWorkExperiences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	education, err := s.Queries.ListEducations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.Queries.ListUserSessions(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := s.Queries.ListPersonalAccessTokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	applications, err := s.Queries.ListApplicationsByApplicant(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	answers, err := s.Queries.ListApplicantAnswers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	savedJobs, err := s.Queries.ListSavedJobPosts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	savedSearches, err := s.Queries.ListSavedSearches(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.Queries.ListAuditEventsByActor(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	experienceList := make([]map[string]any, 0, len(experience))
+	for _, e := range experience {
+		experienceList = append(experienceList, map[string]any{
+			"company":     e.Company,
+			"title":       e.Title,
+			"start_date":  e.StartDate.Format("2006-01"),
+			"end_date":    nullTime(e.EndDate),
+			"description": e.Description,
+		})
+	}
+	educationList := make([]map[string]any, 0, len(education))
+	for _, e := range education {
+		educationList = append(educationList, map[string]any{
+			"school":         e.School,
+			"degree":         e.Degree,
+			"field_of_study": e.FieldOfStudy,
+			"start_year":     e.StartYear,
+			"end_year":       nullInt(e.EndYear),
+		})
+	}
+	sessionList := make([]map[string]any, 0, len(sessions))
+	for _, createdAt := range sessions {
+		sessionList = append(sessionList, map[string]any{"created_at": createdAt.Time})
+	}
+	tokenList := make([]map[string]any, 0, len(tokens))
+	for _, t := range tokens {
+		tokenList = append(tokenList, map[string]any{
+			"name":         t.Name,
+			"prefix":       t.Prefix,
+			"scopes":       t.Scopes,
+			"expires_at":   nullTime(t.ExpiresAt),
+			"last_used_at": nullTime(t.LastUsedAt),
+			"created_at":   t.CreatedAt,
+		})
+	}
+	answerLists := map[uuid.UUID][]map[string]any{}
+	for _, a := range answers {
+		answerLists[a.ApplicationID] = append(answerLists[a.ApplicationID], map[string]any{
+			"question": a.Prompt,
+			"answer":   a.Answer,
+		})
+	}
+	applicationList := make([]map[string]any, 0, len(applications))
+	for _, a := range applications {
+		applicationList = append(applicationList, map[string]any{
+			"job_posting_id":    a.JobPostingID,
+			"company_name":      a.CompanyName,
+			"position":          a.Position,
+			"stage":             a.Stage,
+			"created_at":        a.CreatedAt,
+			"screening_answers": answerLists[a.ID],
+		})
+	}
+	skillList := make([]map[string]any, 0, len(skills))
+	for _, sk := range skills {
+		skillList = append(skillList, map[string]any{
+			"name":        sk.Name,
+			"category":    sk.Category,
+			"proficiency": sk.Proficiency,
+			"years":       nullInt(sk.Years),
+		})
+	}
+	savedJobList := make([]map[string]any, 0, len(savedJobs))
+	for _, j := range savedJobs {
+		savedJobList = append(savedJobList, map[string]any{
+			"job_posting_id": j.ID,
+			"company_name":   j.CompanyName,
+			"position":       j.Position,
+			"saved_at":       j.SavedAt,
+		})
+	}
+	savedSearchList := make([]map[string]any, 0, len(savedSearches))
+	for _, ss := range savedSearches {
+		savedSearchList = append(savedSearchList, map[string]any{
+			"keywords":             ss.Keywords,
+			"skills":               ss.Skills,
+			"min_salary":           nullInt(ss.MinSalary),
+			"match_profile_skills": ss.MatchProfileSkills,
+			"frequency":            ss.Frequency,
+			"created_at":           ss.CreatedAt,
+		})
+	}
+	eventList := make([]map[string]any, 0, len(events))
+	for _, e := range events {
+		eventList = append(eventList, map[string]any{
+			"action":      e.Action,
+			"target_type": e.TargetType,
+			"target_id":   e.TargetID,
+			"ip":          e.Ip.String,
+			"user_agent":  e.UserAgent.String,
+			"created_at":  e.CreatedAt,
+		})
+	}
+
+	return archive(userID, []file{
+		{"user.json", map[string]any{
+			"id":         user.ID,
+			"name":       user.Name,
+			"email":      user.Email,
+			"picture":    user.Picture.String,
+			"role":       user.Role,
+			"status":     user.Status,
+			"created_at": nullTime(user.CreatedAt),
+		}},
+		{"skills.json", skillList},
+		{"profile.json", map[string]any{
+			"headline":           profile.Headline,
+			"location":           profile.Location,
+			"work_authorization": profile.WorkAuthorization,
+			"years_experience":   nullInt(profile.YearsExperience),
+			"github_url":         profile.GithubUrl,
+			"linkedin_url":       profile.LinkedinUrl,
+			"portfolio_url":      profile.PortfolioUrl,
+			"preferred_roles":    profile.PreferredRoles,
+			"preferred_salary":   nullInt(profile.PreferredSalary),
+			"remote_preference":  profile.RemotePreference,
+			"work_history":       experienceList,
+			"education":          educationList,
+		}},
+		{"sessions.json", sessionList},
+		{"access_tokens.json", tokenList},
+		{"applications.json", applicationList},
+		{"saved_jobs.json", savedJobList},
+		{"saved_searches.json", savedSearchList},
+		{"activity.json", eventList},
+	})
+}
+
+// file is one JSON file of an export.
+type file struct {
+	name string
+	data any
+}
+
+// archive zips the JSON files followed by the user's uploads.
+func archive(userID uuid.UUID, files []file) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, err
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := addUploads(zw, userID); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RequestDeletion schedules the account to be anonymized once the grace
+// period has passed. Asking again restarts the grace period.
+func (s *Service) RequestDeletion(ctx context.Context, userID uuid.UUID) (sqlc.AccountDeletionRequest, error) {
+	return s.Queries.RequestAccountDeletion(ctx, sqlc.RequestAccountDeletionParams{
+		UserID:       userID,
+		ScheduledFor: time.Now().Add(s.GracePeriod),
+	})
+}
+
+// Anonymize removes the user's personal fields, sessions, tokens, skills,
+// profile, screening answers, saved jobs and searches, notifications and
+// uploads. The user row itself is kept, soft deleted and renamed, so records
+// that reference it still count towards recruiters' statistics. Audit events
+// are kept too, but lose the user's email, IP address and user agent, and
+// the personal fields of snapshots taken of the user. Uploads are
+// removed before the database changes commit, so a failure leaves the
+// request pending and it is retried.
+func (s *Service) Anonymize(ctx context.Context, userID uuid.UUID) error {
+	return db.InTx(ctx, s.DB, func(q *sqlc.Queries) error {
+		if err := q.DeleteUserSessions(ctx, uuid.NullUUID{UUID: userID, Valid: true}); err != nil {
+			return err
+		}
+		if err := q.DeleteUserTokens(ctx, userID); err != nil {
+			return err
+		}
+		if err := q.DeleteApplicantSkillSet(ctx, userID); err != nil {
+			return err
+		}
+		if err := q.DeleteApplicantSkills(ctx, userID); err != nil {
+			return err
+		}
+		if err := q.DeleteApplicantAnswers(ctx, userID); err != nil {
+			return err
+		}
+		if err := q.DeleteApplicantProfile(ctx, userID); err != nil {
+			return err
+		}
+		if err := q.DeleteWorkExperiences(ctx, userID); err != nil {
+			return err
+		}
+		if err := q.DeleteEducations(ctx, userID); err != nil {
+			return err
+		}
+		if err := q.DeleteSavedJobs(ctx, userID); err != nil {
+			return err
+		}
+		if err := q.DeleteSavedSearches(ctx, userID); err != nil {
+			return err
+		}
+		if err := q.DeleteNotifications(ctx, userID); err != nil {
+			return err
+		}
+		if err := q.AnonymizeUser(ctx, userID); err != nil {
+			return err
+		}
+		if err := q.AllowAuditScrub(ctx); err != nil {
+			return err
+		}
+		if err := q.ScrubAuditEventsByActor(ctx, uuid.NullUUID{UUID: userID, Valid: true}); err != nil {
+			return err
+		}
+		if err := q.ScrubAuditEventsAboutUser(ctx, userID.String()); err != nil {
+			return err
+		}
+		if err := q.CompleteAccountDeletion(ctx, userID); err != nil {
+			return err
+		}
+		return os.RemoveAll(filepath.Join(UploadsDir, userID.String()))
+	})
+}
+
+func addUploads(zw *zip.Writer, userID uuid.UUID) error {
+	root := filepath.Join(UploadsDir, userID.String())
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		w, err := zw.Create("files/" + filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(w, f)
+		return err
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func nullTime(t sql.NullTime) any {
+	if !t.Valid {
+		return nil
+	}
+	return t.Time
+}
+
+func nullInt(n sql.NullInt32) any {
+	if !n.Valid {
+		return nil
+	}
+	return n.Int32
+}
@@ -0,0 +1,254 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"gin-app/db/dbtest"
+	sqlc "gin-app/db/sqlc"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// unzip returns the archive's file names in order and their contents.
+func unzip(t *testing.T, data []byte) ([]string, map[string][]byte) {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	contents := map[string][]byte{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, f.Name)
+		contents[f.Name] = b
+	}
+	return names, contents
+}
+
+func TestArchive(t *testing.T) {
+	UploadsDir = t.TempDir()
+	userID := uuid.New()
+	uploads := map[string]string{
+		"resume.pdf":        "%PDF-1.7",
+		"letters/cover.txt": "Dear hiring manager",
+	}
+	for name, content := range uploads {
+		path := filepath.Join(UploadsDir, userID.String(), name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Another user's uploads must not leak into the archive.
+	if err := os.MkdirAll(filepath.Join(UploadsDir, uuid.NewString()), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := archive(userID, []file{
+		{"user.json", map[string]any{"name": "Ada"}},
+		{"skills.json", []string{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	names, contents := unzip(t, data)
+	want := []string{"user.json", "skills.json", "files/letters/cover.txt", "files/resume.pdf"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("archive files = %q, want %q", names, want)
+	}
+	var user map[string]any
+	if err := json.Unmarshal(contents["user.json"], &user); err != nil || user["name"] != "Ada" {
+		t.Errorf("user.json = %s (%v), want the user", contents["user.json"], err)
+	}
+	if got := string(contents["files/resume.pdf"]); got != uploads["resume.pdf"] {
+		t.Errorf("files/resume.pdf = %q, want %q", got, uploads["resume.pdf"])
+	}
+
+	// A user who never uploaded anything gets just the JSON files.
+	data, err = archive(uuid.New(), []file{{"user.json", map[string]any{}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names, _ := unzip(t, data); !slices.Equal(names, []string{"user.json"}) {
+		t.Errorf("archive files without uploads = %q, want only user.json", names)
+	}
+}
+
+// createApplicant adds an applicant with a session, an event they acted in
+// and an event an admin recorded about them.
+func createApplicant(t *testing.T, conn *sql.DB) sqlc.User {
+	t.Helper()
+	ctx := context.Background()
+	q := sqlc.New(conn)
+	user, err := q.CreateUser(ctx, sqlc.CreateUserParams{
+		Name:   "Test Applicant",
+		Email:  uuid.NewString() + "@example.com",
+		Role:   "applicant",
+		Status: "active",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Exec("DELETE FROM users WHERE id = $1", user.ID) })
+	_, err = q.CreateOrUpdateSession(ctx, sqlc.CreateOrUpdateSessionParams{
+		UserID: uuid.NullUUID{UUID: user.ID, Valid: true},
+		Token:  uuid.NewString(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := []sqlc.CreateAuditEventParams{{
+		ActorID:    uuid.NullUUID{UUID: user.ID, Valid: true},
+		ActorEmail: sql.NullString{String: user.Email, Valid: true},
+		Action:     "applicant.skills_updated",
+		TargetType: "user",
+		TargetID:   user.ID.String(),
+		Before:     json.RawMessage(`{}`),
+		After:      json.RawMessage(`{"skills": ["Go"]}`),
+		Ip:         sql.NullString{String: "203.0.113.7", Valid: true},
+		UserAgent:  sql.NullString{String: "Mozilla/5.0", Valid: true},
+	}, {
+		ActorEmail: sql.NullString{String: "admin@example.com", Valid: true},
+		Action:     "user.suspended",
+		TargetType: "user",
+		TargetID:   user.ID.String(),
+		Before:     json.RawMessage(`{"name": "Test Applicant", "email": "` + user.Email + `", "status": "active"}`),
+		After:      json.RawMessage(`{"name": "Test Applicant", "email": "` + user.Email + `", "status": "suspended", "suspension_reason": "spam"}`),
+		Ip:         sql.NullString{String: "198.51.100.1", Valid: true},
+	}}
+	for _, e := range events {
+		if err := q.CreateAuditEvent(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return user
+}
+
+func TestExport(t *testing.T) {
+	conn := dbtest.Open(t)
+	UploadsDir = t.TempDir()
+	user := createApplicant(t, conn)
+
+	data, err := NewService(conn, sqlc.New(conn), 0).Export(context.Background(), user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names, contents := unzip(t, data)
+	want := []string{
+		"user.json", "skills.json", "profile.json", "sessions.json", "access_tokens.json",
+		"applications.json", "saved_jobs.json", "saved_searches.json", "activity.json",
+	}
+	if !slices.Equal(names, want) {
+		t.Errorf("export files = %q, want %q", names, want)
+	}
+	var activity []map[string]any
+	if err := json.Unmarshal(contents["activity.json"], &activity); err != nil {
+		t.Fatal(err)
+	}
+	if len(activity) != 1 || activity[0]["action"] != "applicant.skills_updated" {
+		t.Errorf("activity.json = %s, want the one event the user acted in", contents["activity.json"])
+	}
+}
+
+func TestAnonymize(t *testing.T) {
+	conn := dbtest.Open(t)
+	UploadsDir = t.TempDir()
+	ctx := context.Background()
+	q := sqlc.New(conn)
+	user := createApplicant(t, conn)
+
+	if err := NewService(conn, q, 0).Anonymize(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := q.GetUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Deleted user" || got.Email == user.Email || !got.DeletedAt.Valid {
+		t.Errorf("user after anonymizing = %q <%s> deleted %v, want renamed and soft deleted", got.Name, got.Email, got.DeletedAt.Valid)
+	}
+	sessions, err := q.ListUserSessions(ctx, uuid.NullUUID{UUID: user.ID, Valid: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 0 {
+		t.Errorf("%d sessions left, want none", len(sessions))
+	}
+
+	rows, err := conn.QueryContext(ctx, `SELECT action, actor_email, ip, user_agent, before, after
+		FROM audit_events WHERE target_type = 'user' AND target_id = $1 ORDER BY id`, user.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	type event struct {
+		actorEmail, ip, userAgent sql.NullString
+		before, after             map[string]any
+	}
+	events := map[string]event{}
+	for rows.Next() {
+		var action string
+		var e event
+		var before, after []byte
+		if err := rows.Scan(&action, &e.actorEmail, &e.ip, &e.userAgent, &before, &after); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(before, &e.before); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(after, &e.after); err != nil {
+			t.Fatal(err)
+		}
+		events[action] = e
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	own := events["applicant.skills_updated"]
+	if own.actorEmail.Valid || own.ip.Valid || own.userAgent.Valid {
+		t.Errorf("event the user acted in kept %v, %v, %v; want no email, IP or user agent", own.actorEmail, own.ip, own.userAgent)
+	}
+	if _, ok := own.after["skills"]; ok {
+		t.Errorf("event the user acted in kept their skills: %v", own.after)
+	}
+	admin := events["user.suspended"]
+	if admin.actorEmail.String != "admin@example.com" || admin.ip.String != "198.51.100.1" {
+		t.Errorf("admin's event lost the admin's details: %v, %v", admin.actorEmail, admin.ip)
+	}
+	for _, snapshot := range []map[string]any{admin.before, admin.after} {
+		for _, key := range []string{"name", "email", "suspension_reason"} {
+			if _, ok := snapshot[key]; ok {
+				t.Errorf("snapshot of the user kept %s: %v", key, snapshot)
+			}
+		}
+	}
+	if admin.after["status"] != "suspended" {
+		t.Errorf("snapshot of the user lost its status: %v", admin.after)
+	}
+
+	// The log stays append-only outside anonymizing.
+	if _, err := conn.ExecContext(ctx, "UPDATE audit_events SET ip = NULL WHERE target_id = $1", user.ID.String()); err == nil {
+		t.Error("updating audit events outside Anonymize succeeded")
+	}
+}
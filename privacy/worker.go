@@ -0,0 +1,58 @@
+package privacy
+
+import (
+	"context"
+	"gin-app/audit"
+	"log/slog"
+	"time"
+)
+
+// Worker anonymizes accounts whose deletion grace period has passed.
+type Worker struct {
+	Service   *Service
+	Audit     *audit.Recorder
+	Interval  time.Duration
+	BatchSize int32
+}
+
+func NewWorker(service *Service, recorder *audit.Recorder) *Worker {
+	return &Worker{
+		Service:   service,
+		Audit:     recorder,
+		Interval:  time.Hour,
+		BatchSize: 50,
+	}
+}
+
+// Run processes due deletions once per Interval until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		if err := w.ProcessDue(ctx); err != nil {
+			slog.Error("processing account deletions", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue anonymizes one batch of accounts. A failure on one account is
+// logged and retried on the next run without blocking the others.
+func (w *Worker) ProcessDue(ctx context.Context) error {
+	userIDs, err := w.Service.Queries.ListDueAccountDeletions(ctx, w.BatchSize)
+	if err != nil {
+		return err
+	}
+	for _, id := range userIDs {
+		if err := w.Service.Anonymize(ctx, id); err != nil {
+			slog.Error("anonymizing user", "user_id", id, "error", err)
+			continue
+		}
+		w.Audit.RecordSystem(ctx, audit.ActionAccountAnonymized, audit.TargetUser, id.String(), nil, nil)
+	}
+	return nil
+}
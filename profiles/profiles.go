@@ -0,0 +1,300 @@
+package profiles
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	sqlc "gin-app/db/sqlc"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// monthLayout is how work history dates are entered, as by an
+// <input type="month">.
+const monthLayout = "2006-01"
+
+// Option is one choice of a profile select field.
+type Option struct {
+	Value string
+	Label string
+}
+
+var (
+	WorkAuthorizations = []Option{
+		{"citizen", "Citizen"},
+		{"permanent_resident", "Permanent resident"},
+		{"work_visa", "Work visa"},
+		{"needs_sponsorship", "Needs sponsorship"},
+	}
+	RemotePreferences = []Option{
+		{"onsite", "On-site"},
+		{"hybrid", "Hybrid"},
+		{"remote", "Remote"},
+	}
+)
+
+var (
+	ErrInvalidChoice   = errors.New("unknown work authorization or remote preference")
+	ErrInvalidYears    = errors.New("years of experience must be a whole number from 0 to 70")
+	ErrInvalidSalary   = errors.New("preferred salary must be a positive whole number")
+	ErrInvalidURL      = errors.New("links must be http or https URLs")
+	ErrMissingField    = errors.New("a required field is empty")
+	ErrInvalidDate     = errors.New("dates must be a month such as 2024-05, and years a four-digit year")
+	ErrDatesOutOfOrder = errors.New("an end date cannot be before its start date")
+	ErrEntryNotFound   = errors.New("profile entry not found")
+)
+
+// Service reads and edits applicant profiles.
+type Service struct {
+	Queries *sqlc.Queries
+}
+
+func NewService(queries *sqlc.Queries) *Service {
+	return &Service{Queries: queries}
+}
+
+// Profile is everything on an applicant's profile. Details is the zero
+// value, apart from the applicant ID, until the applicant first saves it.
+type Profile struct {
+	Details      sqlc.ApplicantProfile
+	Skills       []sqlc.ListApplicantSkillsRow
+	Experience   []sqlc.WorkExperience
+	Education    []sqlc.Education
+	Completeness Completeness
+}
+
+// PreferredRolesText is the preferred roles as the profile form shows them.
+func (p Profile) PreferredRolesText() string {
+	return strings.Join(p.Details.PreferredRoles, ", ")
+}
+
+// Get loads an applicant's profile and scores its completeness.
+func (s *Service) Get(ctx context.Context, applicantID uuid.UUID) (Profile, error) {
+	p := Profile{Details: sqlc.ApplicantProfile{ApplicantID: applicantID}}
+	details, err := s.Queries.GetApplicantProfile(ctx, applicantID)
+	switch {
+	case err == nil:
+		p.Details = details
+	case !errors.Is(err, sql.ErrNoRows):
+		return p, err
+	}
+	if p.Skills, err = s.Queries.ListApplicantSkills(ctx, applicantID); err != nil {
+		return p, err
+	}
+	if p.Experience, err = s.Queries.ListWorkExperiences(ctx, applicantID); err != nil {
+		return p, err
+	}
+	if p.Education, err = s.Queries.ListEducations(ctx, applicantID); err != nil {
+		return p, err
+	}
+	p.Completeness = Score(p)
+	return p, nil
+}
+
+// Update replaces the applicant's profile details with the form's:
+// headline, location, work_authorization, years_experience, github_url,
+// linkedin_url, portfolio_url, preferred_roles (comma-separated),
+// preferred_salary and remote_preference. Empty fields clear the detail.
+func (s *Service) Update(ctx context.Context, applicantID uuid.UUID, form url.Values) (sqlc.ApplicantProfile, error) {
+	params := sqlc.UpsertApplicantProfileParams{
+		ApplicantID:       applicantID,
+		Headline:          text(form, "headline"),
+		Location:          text(form, "location"),
+		WorkAuthorization: text(form, "work_authorization"),
+		RemotePreference:  text(form, "remote_preference"),
+		PreferredRoles:    list(form.Get("preferred_roles")),
+	}
+	if !valid(WorkAuthorizations, params.WorkAuthorization) || !valid(RemotePreferences, params.RemotePreference) {
+		return sqlc.ApplicantProfile{}, ErrInvalidChoice
+	}
+	var err error
+	if params.YearsExperience, err = number(form, "years_experience", 0, 70); err != nil {
+		return sqlc.ApplicantProfile{}, ErrInvalidYears
+	}
+	if params.PreferredSalary, err = number(form, "preferred_salary", 1, 1<<31-1); err != nil {
+		return sqlc.ApplicantProfile{}, ErrInvalidSalary
+	}
+	for _, link := range []struct {
+		key string
+		dst *string
+	}{
+		{"github_url", &params.GithubUrl},
+		{"linkedin_url", &params.LinkedinUrl},
+		{"portfolio_url", &params.PortfolioUrl},
+	} {
+		if *link.dst, err = httpURL(text(form, link.key)); err != nil {
+			return sqlc.ApplicantProfile{}, err
+		}
+	}
+	return s.Queries.UpsertApplicantProfile(ctx, params)
+}
+
+// AddExperience adds a work history entry from the form's company, title,
+// start_date, end_date and description. Dates are months; an empty
+// end_date means the applicant still works there.
+func (s *Service) AddExperience(ctx context.Context, applicantID uuid.UUID, form url.Values) (sqlc.WorkExperience, error) {
+	params := sqlc.CreateWorkExperienceParams{
+		ApplicantID: applicantID,
+		Company:     text(form, "company"),
+		Title:       text(form, "title"),
+		Description: strings.TrimSpace(form.Get("description")),
+	}
+	if params.Company == "" || params.Title == "" || form.Get("start_date") == "" {
+		return sqlc.WorkExperience{}, ErrMissingField
+	}
+	start, err := time.Parse(monthLayout, form.Get("start_date"))
+	if err != nil {
+		return sqlc.WorkExperience{}, ErrInvalidDate
+	}
+	params.StartDate = start
+	if end := form.Get("end_date"); end != "" {
+		t, err := time.Parse(monthLayout, end)
+		if err != nil {
+			return sqlc.WorkExperience{}, ErrInvalidDate
+		}
+		if t.Before(start) {
+			return sqlc.WorkExperience{}, ErrDatesOutOfOrder
+		}
+		params.EndDate = sql.NullTime{Time: t, Valid: true}
+	}
+	return s.Queries.CreateWorkExperience(ctx, params)
+}
+
+// RemoveExperience deletes one of the applicant's work history entries.
+func (s *Service) RemoveExperience(ctx context.Context, applicantID, id uuid.UUID) error {
+	n, err := s.Queries.DeleteWorkExperience(ctx, sqlc.DeleteWorkExperienceParams{ID: id, ApplicantID: applicantID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrEntryNotFound
+	}
+	return nil
+}
+
+// AddEducation adds an education entry from the form's school, degree,
+// field_of_study, start_year and end_year. An empty end_year means the
+// applicant is still studying.
+func (s *Service) AddEducation(ctx context.Context, applicantID uuid.UUID, form url.Values) (sqlc.Education, error) {
+	params := sqlc.CreateEducationParams{
+		ApplicantID:  applicantID,
+		School:       text(form, "school"),
+		Degree:       text(form, "degree"),
+		FieldOfStudy: text(form, "field_of_study"),
+	}
+	if params.School == "" || form.Get("start_year") == "" {
+		return sqlc.Education{}, ErrMissingField
+	}
+	start, err := number(form, "start_year", 1900, 2100)
+	if err != nil {
+		return sqlc.Education{}, ErrInvalidDate
+	}
+	params.StartYear = start.Int32
+	if params.EndYear, err = number(form, "end_year", 1900, 2100); err != nil {
+		return sqlc.Education{}, ErrInvalidDate
+	}
+	if params.EndYear.Valid && params.EndYear.Int32 < params.StartYear {
+		return sqlc.Education{}, ErrDatesOutOfOrder
+	}
+	return s.Queries.CreateEducation(ctx, params)
+}
+
+// RemoveEducation deletes one of the applicant's education entries.
+func (s *Service) RemoveEducation(ctx context.Context, applicantID, id uuid.UUID) error {
+	n, err := s.Queries.DeleteEducation(ctx, sqlc.DeleteEducationParams{ID: id, ApplicantID: applicantID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrEntryNotFound
+	}
+	return nil
+}
+
+// Completeness is how much of a profile is filled in, and what is left.
+type Completeness struct {
+	Percent int
+	Missing []string
+}
+
+// Score weighs each part of the profile equally.
+func Score(p Profile) Completeness {
+	d := p.Details
+	parts := []struct {
+		name string
+		done bool
+	}{
+		{"a headline", d.Headline != ""},
+		{"your location", d.Location != ""},
+		{"your work authorization", d.WorkAuthorization != ""},
+		{"years of experience", d.YearsExperience.Valid},
+		{"your skills", len(p.Skills) > 0},
+		{"work history", len(p.Experience) > 0},
+		{"education", len(p.Education) > 0},
+		{"a GitHub, LinkedIn or portfolio link", d.GithubUrl != "" || d.LinkedinUrl != "" || d.PortfolioUrl != ""},
+		{"your job preferences", len(d.PreferredRoles) > 0 || d.PreferredSalary.Valid || d.RemotePreference != ""},
+	}
+	var c Completeness
+	done := 0
+	for _, part := range parts {
+		if part.done {
+			done++
+		} else {
+			c.Missing = append(c.Missing, part.name)
+		}
+	}
+	c.Percent = 100 * done / len(parts)
+	return c
+}
+
+func text(form url.Values, key string) string {
+	return strings.Join(strings.Fields(form.Get(key)), " ")
+}
+
+func list(s string) []string {
+	items := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func valid(options []Option, value string) bool {
+	return value == "" || slices.ContainsFunc(options, func(o Option) bool { return o.Value == value })
+}
+
+// number parses an optional whole number between lo and hi.
+func number(form url.Values, key string, lo, hi int64) (sql.NullInt32, error) {
+	s := strings.TrimSpace(form.Get(key))
+	if s == "" {
+		return sql.NullInt32{}, nil
+	}
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return sql.NullInt32{}, err
+	}
+	if n < lo || n > hi {
+		return sql.NullInt32{}, strconv.ErrRange
+	}
+	return sql.NullInt32{Int32: int32(n), Valid: true}, nil
+}
+
+// httpURL accepts an empty link or an absolute http(s) URL, so a profile
+// link can never be a javascript: URL.
+func httpURL(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", ErrInvalidURL
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,60 @@
+package profiles
+
+import (
+	"database/sql"
+	sqlc "gin-app/db/sqlc"
+	"slices"
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	complete := Profile{
+		Details: sqlc.ApplicantProfile{
+			Headline:          "Backend engineer",
+			Location:          "Berlin",
+			WorkAuthorization: "citizen",
+			YearsExperience:   sql.NullInt32{Int32: 0, Valid: true},
+			GithubUrl:         "https://github.com/example",
+			RemotePreference:  "remote",
+		},
+		Skills:     []sqlc.ListApplicantSkillsRow{{Name: "Go"}},
+		Experience: []sqlc.WorkExperience{{Title: "Engineer"}},
+		Education:  []sqlc.Education{{School: "TU Berlin"}},
+	}
+
+	tests := []struct {
+		name        string
+		change      func(*Profile)
+		wantPercent int
+		wantMissing []string
+	}{
+		{"complete", func(*Profile) {}, 100, nil},
+		{"empty", func(p *Profile) { *p = Profile{} }, 0, []string{
+			"a headline", "your location", "your work authorization", "years of experience", "your skills",
+			"work history", "education", "a GitHub, LinkedIn or portfolio link", "your job preferences",
+		}},
+		{"no headline", func(p *Profile) { p.Details.Headline = "" }, 88, []string{"a headline"}},
+		{"zero years still counts", func(p *Profile) { p.Details.YearsExperience = sql.NullInt32{Valid: true} }, 100, nil},
+		{"no years", func(p *Profile) { p.Details.YearsExperience = sql.NullInt32{} }, 88, []string{"years of experience"}},
+		{"any one link", func(p *Profile) {
+			p.Details.GithubUrl = ""
+			p.Details.PortfolioUrl = "https://example.com"
+		}, 100, nil},
+		{"no links", func(p *Profile) { p.Details.GithubUrl = "" }, 88, []string{"a GitHub, LinkedIn or portfolio link"}},
+		{"any one preference", func(p *Profile) {
+			p.Details.RemotePreference = ""
+			p.Details.PreferredRoles = []string{"Backend"}
+		}, 100, nil},
+		{"no lists", func(p *Profile) {
+			p.Skills, p.Experience, p.Education = nil, nil, nil
+		}, 66, []string{"your skills", "work history", "education"}},
+	}
+	for _, tt := range tests {
+		p := complete
+		tt.change(&p)
+		got := Score(p)
+		if got.Percent != tt.wantPercent || !slices.Equal(got.Missing, tt.wantMissing) {
+			t.Errorf("%s: Score = %d%% missing %q, want %d%% missing %q", tt.name, got.Percent, got.Missing, tt.wantPercent, tt.wantMissing)
+		}
+	}
+}
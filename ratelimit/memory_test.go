@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"context"
+	"gin-app/config"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreTake(t *testing.T) {
+	limit := config.RateLimit{PerMinute: 60, Burst: 3}
+	type take struct {
+		advance time.Duration
+		key     string
+		ok      bool
+		wait    time.Duration
+	}
+	tests := []struct {
+		name  string
+		takes []take
+	}{
+		{"burst then empty", []take{
+			{0, "a", true, 0},
+			{0, "a", true, 0},
+			{0, "a", true, 0},
+			{0, "a", false, time.Second},
+		}},
+		{"partial refill", []take{
+			{0, "a", true, 0},
+			{0, "a", true, 0},
+			{0, "a", true, 0},
+			{250 * time.Millisecond, "a", false, 750 * time.Millisecond},
+			{750 * time.Millisecond, "a", true, 0},
+			{0, "a", false, time.Second},
+		}},
+		{"keys are separate", []take{
+			{0, "a", true, 0},
+			{0, "a", true, 0},
+			{0, "a", true, 0},
+			{0, "b", true, 0},
+			{0, "a", false, time.Second},
+		}},
+		{"refill is capped at burst", []take{
+			{0, "a", true, 0},
+			{30 * time.Minute, "a", true, 0},
+			{0, "a", true, 0},
+			{0, "a", true, 0},
+			{0, "a", false, time.Second},
+		}},
+		{"denied takes spend nothing", []take{
+			{0, "a", true, 0},
+			{0, "a", true, 0},
+			{0, "a", true, 0},
+			{0, "a", false, time.Second},
+			{0, "a", false, time.Second},
+			{time.Second, "a", true, 0},
+		}},
+	}
+	for _, tt := range tests {
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		s := NewMemoryStore()
+		s.now = func() time.Time { return now }
+		for i, tk := range tt.takes {
+			now = now.Add(tk.advance)
+			ok, wait, err := s.Take(context.Background(), tk.key, limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tk.ok || wait.Round(time.Millisecond) != tk.wait {
+				t.Errorf("%s: take %d = %v, %v; want %v, %v", tt.name, i+1, ok, wait, tk.ok, tk.wait)
+			}
+		}
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	limit := config.RateLimit{PerMinute: 1, Burst: 1}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	s.Take(ctx, "idle", limit)
+	now = now.Add(30 * time.Minute)
+	s.Take(ctx, "recent", limit)
+	now = now.Add(31 * time.Minute)
+	s.Take(ctx, "new", limit)
+
+	if _, ok := s.buckets["idle"]; ok {
+		t.Error("bucket idle for over an hour was kept")
+	}
+	if _, ok := s.buckets["recent"]; !ok {
+		t.Error("bucket used 31 minutes ago was dropped")
+	}
+
+	// A dropped bucket starts full again.
+	if ok, _, _ := s.Take(ctx, "idle", limit); !ok {
+		t.Error("take from a swept bucket was denied")
+	}
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"gin-app/config"
+	db "gin-app/db/sqlc"
+	"log/slog"
+	"time"
+)
+
+// PostgresStore keeps buckets in the rate_limit_buckets table so replicas
+// share them. Refilling and taking happen in one upsert, so concurrent
+// requests for the same key cannot both spend the last token.
+type PostgresStore struct {
+	Queries  *db.Queries
+	IdleTTL  time.Duration
+	Interval time.Duration
+}
+
+func NewPostgresStore(queries *db.Queries) *PostgresStore {
+	return &PostgresStore{
+		Queries:  queries,
+		IdleTTL:  time.Hour,
+		Interval: 10 * time.Minute,
+	}
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, limit config.RateLimit) (bool, time.Duration, error) {
+	_, err := s.Queries.TakeRateLimitToken(ctx, db.TakeRateLimitTokenParams{
+		Key:       key,
+		Burst:     float64(limit.Burst),
+		PerSecond: perSecond(limit),
+	})
+	if err == nil {
+		return true, 0, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, 0, err
+	}
+	wait, err := s.Queries.GetRateLimitWait(ctx, db.GetRateLimitWaitParams{
+		Burst:     float64(limit.Burst),
+		PerSecond: perSecond(limit),
+		Key:       key,
+	})
+	if err != nil {
+		return false, 0, err
+	}
+	return false, time.Duration(wait * float64(time.Second)), nil
+}
+
+func (s *PostgresStore) Refund(ctx context.Context, key string, limit config.RateLimit) error {
+	return s.Queries.RefundRateLimitToken(ctx, db.RefundRateLimitTokenParams{
+		Key:   key,
+		Burst: float64(limit.Burst),
+	})
+}
+
+// Run deletes buckets idle for longer than IdleTTL once per Interval until
+// ctx is cancelled.
+func (s *PostgresStore) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.Queries.DeleteIdleRateLimitBuckets(ctx, time.Now().Add(-s.IdleTTL)); err != nil {
+			slog.Error("deleting idle rate limit buckets", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"context"
+	"gin-app/config"
+	"gin-app/logging"
+	"gin-app/metrics"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// Store keeps token buckets by key. Take removes one token from the bucket,
+// creating it full if needed, and reports whether one was available and, if
+// not, how long until the next one is. Refund puts back a token Take
+// removed, up to the burst.
+type Store interface {
+	Take(ctx context.Context, key string, limit config.RateLimit) (bool, time.Duration, error)
+	Refund(ctx context.Context, key string, limit config.RateLimit) error
+}
+
+// Limiter applies one rate limit to a group of routes. Every request spends a
+// token from its client IP's bucket and, when signed in, from its user's
+// bucket too, so neither switching networks nor sharing one helps. A request
+// turned away spends nothing, so one user hitting their limit does not use
+// up the allowance of others behind the same IP.
+type Limiter struct {
+	Name  string
+	Store Store
+	Limit config.RateLimit
+}
+
+func NewLimiter(name string, store Store, limit config.RateLimit) *Limiter {
+	return &Limiter{Name: name, Store: store, Limit: limit}
+}
+
+// Middleware responds 429 with a Retry-After header once a bucket is empty.
+// If the store fails the request is let through: a database hiccup should not
+// lock everyone out of signing in.
+func (l *Limiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		keys := []string{l.Name + ":ip:" + c.ClientIP()}
+		if id := userID(c); id != "" {
+			keys = append(keys, l.Name+":user:"+id)
+		}
+		for i, key := range keys {
+			ok, wait, err := l.Store.Take(c.Request.Context(), key, l.Limit)
+			if err != nil {
+				logging.FromGin(c).Warn("rate limit store failed", "limiter", l.Name, "error", err)
+				break
+			}
+			if !ok {
+				for _, taken := range keys[:i] {
+					if err := l.Store.Refund(c.Request.Context(), taken, l.Limit); err != nil {
+						logging.FromGin(c).Warn("rate limit store failed", "limiter", l.Name, "error", err)
+					}
+				}
+				metrics.RateLimited.WithLabelValues(l.Name).Inc()
+				c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests, try again later"})
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// userID prefers the identity set by the token middleware and falls back to
+// the cookie session.
+func userID(c *gin.Context) string {
+	if id := c.GetString("id"); id != "" {
+		return id
+	}
+	id, _ := sessions.Default(c).Get("id").(string)
+	return id
+}
+
+func perSecond(limit config.RateLimit) float64 {
+	return limit.PerMinute / 60
+}
+
+// Limiters are the limits for the route groups that need one: signing in,
+// applying to jobs and uploading files.
+type Limiters struct {
+	Auth   *Limiter
+	Apply  *Limiter
+	Upload *Limiter
+}
+
+func NewLimiters(store Store, cfg config.RateLimitConfig) Limiters {
+	return Limiters{
+		Auth:   NewLimiter("auth", store, cfg.Auth),
+		Apply:  NewLimiter("apply", store, cfg.Apply),
+		Upload: NewLimiter("upload", store, cfg.Upload),
+	}
+}
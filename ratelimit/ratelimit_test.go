@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"gin-app/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+)
+
+func TestLimiterMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limit := config.RateLimit{PerMinute: 1, Burst: 2}
+	type request struct {
+		ip, user string
+		want     int
+	}
+	tests := []struct {
+		name     string
+		requests []request
+	}{
+		{"per IP", []request{
+			{"192.0.2.1", "", http.StatusOK},
+			{"192.0.2.1", "", http.StatusOK},
+			{"192.0.2.1", "", http.StatusTooManyRequests},
+			{"192.0.2.2", "", http.StatusOK},
+		}},
+		{"per user across IPs", []request{
+			{"192.0.2.1", "ada", http.StatusOK},
+			{"192.0.2.2", "ada", http.StatusOK},
+			{"192.0.2.3", "ada", http.StatusTooManyRequests},
+		}},
+		{"a rejected user spends nothing from the IP", []request{
+			{"192.0.2.1", "ada", http.StatusOK},
+			{"192.0.2.2", "ada", http.StatusOK},
+			{"192.0.2.3", "ada", http.StatusTooManyRequests},
+			{"192.0.2.3", "ada", http.StatusTooManyRequests},
+			{"192.0.2.3", "grace", http.StatusOK},
+			{"192.0.2.3", "linus", http.StatusOK},
+			{"192.0.2.3", "ken", http.StatusTooManyRequests},
+		}},
+	}
+	for _, tt := range tests {
+		limiter := NewLimiter("test", NewMemoryStore(), limit)
+		r := gin.New()
+		r.Use(sessions.Sessions("session", cookie.NewStore([]byte("secret"))), func(c *gin.Context) {
+			if user := c.GetHeader("X-User"); user != "" {
+				c.Set("id", user)
+			}
+		})
+		r.GET("/", limiter.Middleware(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		for i, req := range tt.requests {
+			w := httptest.NewRecorder()
+			httpReq := httptest.NewRequest(http.MethodGet, "/", nil)
+			httpReq.RemoteAddr = req.ip + ":1234"
+			httpReq.Header.Set("X-User", req.user)
+			r.ServeHTTP(w, httpReq)
+			if w.Code != req.want {
+				t.Errorf("%s: request %d from %s as %q = %d, want %d", tt.name, i+1, req.ip, req.user, w.Code, req.want)
+			}
+			if w.Code == http.StatusTooManyRequests && w.Header().Get("Retry-After") == "" {
+				t.Errorf("%s: request %d was limited without a Retry-After header", tt.name, i+1)
+			}
+		}
+	}
+}
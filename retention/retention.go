@@ -0,0 +1,76 @@
+package retention
+
+import (
+	"context"
+	"database/sql"
+	"gin-app/audit"
+	db "gin-app/db/sqlc"
+	"log/slog"
+	"time"
+)
+
+// Purger permanently deletes users, companies and job postings that were
+// soft deleted longer than Window ago. Until then an admin can restore them.
+// Rows that applications still refer to are kept, since applications are
+// the hiring company's record and are never deleted with them.
+type Purger struct {
+	Queries  *db.Queries
+	Audit    *audit.Recorder
+	Window   time.Duration
+	Interval time.Duration
+}
+
+func NewPurger(queries *db.Queries, recorder *audit.Recorder, window time.Duration) *Purger {
+	return &Purger{
+		Queries:  queries,
+		Audit:    recorder,
+		Window:   window,
+		Interval: time.Hour,
+	}
+}
+
+// Run purges once per Interval until ctx is cancelled.
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+	for {
+		if err := p.Purge(ctx); err != nil {
+			slog.Error("purging soft deleted rows", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge deletes everything past the retention window, children before
+// parents to mirror the foreign keys.
+func (p *Purger) Purge(ctx context.Context) error {
+	cutoff := sql.NullTime{Time: time.Now().Add(-p.Window), Valid: true}
+
+	jobPosts, err := p.Queries.PurgeDeletedJobPosts(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+	companies, err := p.Queries.PurgeDeletedCompanies(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+	users, err := p.Queries.PurgeDeletedUsers(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+
+	if jobPosts+companies+users > 0 {
+		slog.Info("purged soft deleted rows", "job_posts", jobPosts, "companies", companies, "users", users, "cutoff", cutoff.Time)
+		p.Audit.RecordSystem(ctx, audit.ActionRetentionPurged, audit.TargetSystem, "retention", nil, map[string]any{
+			"cutoff":    cutoff.Time,
+			"job_posts": jobPosts,
+			"companies": companies,
+			"users":     users,
+		})
+	}
+	return nil
+}
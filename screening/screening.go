@@ -0,0 +1,367 @@
+package screening
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"gin-app/analytics"
+	"gin-app/db"
+	sqlc "gin-app/db/sqlc"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of screening question.
+const (
+	KindText         = "text"
+	KindYesNo        = "yes_no"
+	KindSingleChoice = "single_choice"
+	KindMultiChoice  = "multi_choice"
+	KindNumber       = "number"
+)
+
+// Option is one choice of a select field.
+type Option struct {
+	Value string
+	Label string
+}
+
+var Kinds = []Option{
+	{KindText, "Free text"},
+	{KindYesNo, "Yes / No"},
+	{KindSingleChoice, "Single choice"},
+	{KindMultiChoice, "Multiple choice"},
+	{KindNumber, "Number"},
+}
+
+// yesNo are the options of every yes/no question.
+var yesNo = []string{"Yes", "No"}
+
+// FieldPrefix starts the name of the apply form field holding the answer to
+// a question; the rest is the question's ID.
+const FieldPrefix = "question-"
+
+var (
+	ErrMissingPrompt    = errors.New("a question needs a prompt")
+	ErrInvalidKind      = errors.New("unknown question type")
+	ErrMissingOptions   = errors.New("a choice question needs at least two different options")
+	ErrInvalidKnockout  = errors.New("knockout answers must be among the question's options, and knockout limits whole numbers with the minimum not above the maximum")
+	ErrQuestionNotFound = errors.New("screening question not found")
+	ErrAnswerRequired   = errors.New("an answer is required")
+	ErrInvalidAnswer    = errors.New("the answer must be one of the question's options, or a whole number for a number question")
+
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrInvalidStage        = errors.New("unknown application stage")
+	ErrStageUnchanged      = errors.New("the application is already in that stage")
+	ErrApplicationHired    = errors.New("a hired application cannot change stage")
+)
+
+// Service manages the screening questions of postings and the answers
+// applicants give to them.
+type Service struct {
+	DB      db.TxBeginner
+	Queries *sqlc.Queries
+}
+
+func NewService(conn db.TxBeginner, queries *sqlc.Queries) *Service {
+	return &Service{DB: conn, Queries: queries}
+}
+
+// ParseQuestion reads a question from the recruiter's form: prompt, kind,
+// options (one per line, for choice questions), required, and the knockout
+// rule. An applicant is rejected automatically if they pick one of
+// knockout_answers (one per line) on a yes/no or choice question, or answer
+// a number question outside knockout_min and knockout_max. Free text
+// questions have no knockout rule.
+func ParseQuestion(jobPostingID uuid.UUID, form url.Values) (sqlc.CreateScreeningQuestionParams, error) {
+	params := sqlc.CreateScreeningQuestionParams{
+		JobPostingID:    jobPostingID,
+		Prompt:          strings.Join(strings.Fields(form.Get("prompt")), " "),
+		Kind:            form.Get("kind"),
+		Options:         []string{},
+		Required:        form.Get("required") == "on",
+		KnockoutAnswers: []string{},
+	}
+	if params.Prompt == "" {
+		return params, ErrMissingPrompt
+	}
+	knockouts := lines(form.Get("knockout_answers"))
+	switch params.Kind {
+	case KindText:
+		if len(knockouts) > 0 || form.Get("knockout_min") != "" || form.Get("knockout_max") != "" {
+			return params, ErrInvalidKnockout
+		}
+	case KindNumber:
+		var err error
+		if params.KnockoutMin, err = number(form.Get("knockout_min")); err != nil {
+			return params, ErrInvalidKnockout
+		}
+		if params.KnockoutMax, err = number(form.Get("knockout_max")); err != nil {
+			return params, ErrInvalidKnockout
+		}
+		if len(knockouts) > 0 || params.KnockoutMin.Valid && params.KnockoutMax.Valid && params.KnockoutMin.Int32 > params.KnockoutMax.Int32 {
+			return params, ErrInvalidKnockout
+		}
+	case KindYesNo, KindSingleChoice, KindMultiChoice:
+		params.Options = yesNo
+		if params.Kind != KindYesNo {
+			params.Options = []string{}
+			for _, option := range lines(form.Get("options")) {
+				if _, dup := match(params.Options, option); !dup {
+					params.Options = append(params.Options, option)
+				}
+			}
+			if len(params.Options) < 2 {
+				return params, ErrMissingOptions
+			}
+		}
+		for _, answer := range knockouts {
+			option, ok := match(params.Options, answer)
+			if !ok {
+				return params, ErrInvalidKnockout
+			}
+			if !slices.Contains(params.KnockoutAnswers, option) {
+				params.KnockoutAnswers = append(params.KnockoutAnswers, option)
+			}
+		}
+	default:
+		return params, ErrInvalidKind
+	}
+	return params, nil
+}
+
+// Questions lists a posting's questions in the order they are asked.
+func (s *Service) Questions(ctx context.Context, jobPostingID uuid.UUID) ([]sqlc.ScreeningQuestion, error) {
+	return s.Queries.ListScreeningQuestions(ctx, jobPostingID)
+}
+
+// AddQuestion adds a question after the posting's existing ones.
+func (s *Service) AddQuestion(ctx context.Context, params sqlc.CreateScreeningQuestionParams) (sqlc.ScreeningQuestion, error) {
+	return s.Queries.CreateScreeningQuestion(ctx, params)
+}
+
+// RemoveQuestion deletes one of a posting's questions. Answers already
+// given to it are kept with the application.
+func (s *Service) RemoveQuestion(ctx context.Context, jobPostingID, id uuid.UUID) error {
+	n, err := s.Queries.DeleteScreeningQuestion(ctx, sqlc.DeleteScreeningQuestionParams{ID: id, JobPostingID: jobPostingID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrQuestionNotFound
+	}
+	return nil
+}
+
+// Answer is an applicant's answer to one question. Values holds one value,
+// or none if an optional question was skipped, except for multiple choice.
+type Answer struct {
+	Question   sqlc.ScreeningQuestion
+	Values     []string
+	KnockedOut bool
+}
+
+// Evaluate checks the apply form's answers to questions and applies their
+// knockout rules. Choice answers are stored as the option's own text.
+func Evaluate(questions []sqlc.ScreeningQuestion, form url.Values) ([]Answer, error) {
+	answers := make([]Answer, 0, len(questions))
+	for _, q := range questions {
+		values := []string{}
+		for _, v := range form[FieldPrefix+q.ID.String()] {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		a := Answer{Question: q, Values: values}
+		if len(values) == 0 {
+			if q.Required {
+				return nil, fmt.Errorf("%s: %w", q.Prompt, ErrAnswerRequired)
+			}
+			answers = append(answers, a)
+			continue
+		}
+		if q.Kind != KindMultiChoice {
+			a.Values = values[:1]
+		}
+		switch q.Kind {
+		case KindNumber:
+			n, err := strconv.ParseInt(a.Values[0], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", q.Prompt, ErrInvalidAnswer)
+			}
+			a.KnockedOut = q.KnockoutMin.Valid && n < int64(q.KnockoutMin.Int32) ||
+				q.KnockoutMax.Valid && n > int64(q.KnockoutMax.Int32)
+		case KindYesNo, KindSingleChoice, KindMultiChoice:
+			picked := []string{}
+			for _, v := range a.Values {
+				option, ok := match(q.Options, v)
+				if !ok {
+					return nil, fmt.Errorf("%s: %w", q.Prompt, ErrInvalidAnswer)
+				}
+				if !slices.Contains(picked, option) {
+					picked = append(picked, option)
+				}
+				if slices.Contains(q.KnockoutAnswers, option) {
+					a.KnockedOut = true
+				}
+			}
+			a.Values = picked
+		}
+		answers = append(answers, a)
+	}
+	return answers, nil
+}
+
+// Apply creates an application with the applicant's answers to the
+// posting's questions. If any answer trips a knockout rule the application
+// is rejected straight away, after it enters the applied stage, so the
+// hiring funnel still counts it; the second result reports this. As with
+// CreateApplication, sql.ErrNoRows means the applicant already applied.
+func (s *Service) Apply(ctx context.Context, params sqlc.CreateApplicationParams, form url.Values) (sqlc.Application, bool, error) {
+	questions, err := s.Queries.ListScreeningQuestions(ctx, params.JobPostingID)
+	if err != nil {
+		return sqlc.Application{}, false, err
+	}
+	answers, err := Evaluate(questions, form)
+	if err != nil {
+		return sqlc.Application{}, false, err
+	}
+	knockedOut := slices.ContainsFunc(answers, func(a Answer) bool { return a.KnockedOut })
+
+	var application sqlc.Application
+	err = db.InTx(ctx, s.DB, func(q *sqlc.Queries) error {
+		var err error
+		if application, err = q.CreateApplication(ctx, params); err != nil {
+			return err
+		}
+		for i, a := range answers {
+			err := q.AddApplicationAnswer(ctx, sqlc.AddApplicationAnswerParams{
+				ApplicationID: application.ID,
+				QuestionID:    uuid.NullUUID{UUID: a.Question.ID, Valid: true},
+				Position:      int32(i + 1),
+				Prompt:        a.Question.Prompt,
+				Answer:        a.Values,
+				KnockedOut:    a.KnockedOut,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if knockedOut {
+			application.Stage = "rejected"
+			return q.RejectApplication(ctx, application.ID)
+		}
+		return nil
+	})
+	return application, knockedOut, err
+}
+
+// CheckStage reports whether an application in stage from may move to
+// stage to. Any stage may follow any other, so recruiters can skip ahead,
+// step back or reopen a rejection, but a hire is final.
+func CheckStage(from, to string) error {
+	switch {
+	case !slices.Contains(analytics.Stages, to):
+		return ErrInvalidStage
+	case from == to:
+		return ErrStageUnchanged
+	case from == "hired":
+		return ErrApplicationHired
+	}
+	return nil
+}
+
+// MoveStage moves one of a posting's applications to stage, returning it
+// before and after the move. The stage history the analytics read is kept
+// by a trigger on the applications table.
+func (s *Service) MoveStage(ctx context.Context, jobPostingID, applicationID uuid.UUID, stage string) (before, after sqlc.Application, err error) {
+	err = db.InTx(ctx, s.DB, func(q *sqlc.Queries) error {
+		var err error
+		before, err = q.GetJobPostApplicationForUpdate(ctx, sqlc.GetJobPostApplicationForUpdateParams{
+			ID:           applicationID,
+			JobPostingID: jobPostingID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrApplicationNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := CheckStage(before.Stage, stage); err != nil {
+			return err
+		}
+		after, err = q.UpdateApplicationStage(ctx, sqlc.UpdateApplicationStageParams{ID: applicationID, Stage: stage})
+		return err
+	})
+	return before, after, err
+}
+
+// Application is an application to a posting with the answers given.
+type Application struct {
+	sqlc.ListJobPostApplicationsRow
+	Answers    []sqlc.ListJobPostAnswersRow
+	KnockedOut bool
+}
+
+// Applications lists a posting's applications, newest first, each with its
+// answers in the order they were asked.
+func (s *Service) Applications(ctx context.Context, jobPostingID uuid.UUID) ([]Application, error) {
+	rows, err := s.Queries.ListJobPostApplications(ctx, jobPostingID)
+	if err != nil {
+		return nil, err
+	}
+	answers, err := s.Queries.ListJobPostAnswers(ctx, jobPostingID)
+	if err != nil {
+		return nil, err
+	}
+	byApplication := map[uuid.UUID][]sqlc.ListJobPostAnswersRow{}
+	for _, a := range answers {
+		byApplication[a.ApplicationID] = append(byApplication[a.ApplicationID], a)
+	}
+	out := make([]Application, 0, len(rows))
+	for _, row := range rows {
+		a := Application{ListJobPostApplicationsRow: row, Answers: byApplication[row.ID]}
+		a.KnockedOut = slices.ContainsFunc(a.Answers, func(ans sqlc.ListJobPostAnswersRow) bool { return ans.KnockedOut })
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+// match finds the option value names, ignoring case and surrounding space.
+func match(options []string, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	for _, option := range options {
+		if strings.EqualFold(option, value) {
+			return option, true
+		}
+	}
+	return "", false
+}
+
+func lines(s string) []string {
+	out := []string{}
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+// number parses an optional whole number.
+func number(s string) (sql.NullInt32, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return sql.NullInt32{}, nil
+	}
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return sql.NullInt32{}, err
+	}
+	return sql.NullInt32{Int32: int32(n), Valid: true}, nil
+}
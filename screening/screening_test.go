@@ -0,0 +1,156 @@
+package screening
+
+import (
+	"database/sql"
+	"errors"
+	sqlc "gin-app/db/sqlc"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseQuestion(t *testing.T) {
+	bound := func(n int32) sql.NullInt32 { return sql.NullInt32{Int32: n, Valid: true} }
+	tests := []struct {
+		name    string
+		form    url.Values
+		want    sqlc.CreateScreeningQuestionParams
+		wantErr error
+	}{
+		{"text", url.Values{"prompt": {"  Why   us? "}, "kind": {KindText}, "required": {"on"}}, sqlc.CreateScreeningQuestionParams{
+			Prompt: "Why us?", Kind: KindText, Options: []string{}, Required: true, KnockoutAnswers: []string{},
+		}, nil},
+		{"text with knockout", url.Values{"prompt": {"Why us?"}, "kind": {KindText}, "knockout_answers": {"money"}}, sqlc.CreateScreeningQuestionParams{}, ErrInvalidKnockout},
+		{"yes/no knockout", url.Values{"prompt": {"Work permit?"}, "kind": {KindYesNo}, "knockout_answers": {" no \n"}}, sqlc.CreateScreeningQuestionParams{
+			Prompt: "Work permit?", Kind: KindYesNo, Options: []string{"Yes", "No"}, KnockoutAnswers: []string{"No"},
+		}, nil},
+		{"yes/no ignores options", url.Values{"prompt": {"Relocate?"}, "kind": {KindYesNo}, "options": {"Maybe"}}, sqlc.CreateScreeningQuestionParams{
+			Prompt: "Relocate?", Kind: KindYesNo, Options: []string{"Yes", "No"}, KnockoutAnswers: []string{},
+		}, nil},
+		{"choice options deduplicated", url.Values{
+			"prompt":           {"Start date?"},
+			"kind":             {KindSingleChoice},
+			"options":          {"Now\r\nIn a month\n\nnow\nIn  a  year"},
+			"knockout_answers": {"in a year\nIN A YEAR"},
+		}, sqlc.CreateScreeningQuestionParams{
+			Prompt: "Start date?", Kind: KindSingleChoice, Options: []string{"Now", "In a month", "In a year"}, KnockoutAnswers: []string{"In a year"},
+		}, nil},
+		{"choice knockout not an option", url.Values{"prompt": {"Level?"}, "kind": {KindMultiChoice}, "options": {"A\nB"}, "knockout_answers": {"C"}}, sqlc.CreateScreeningQuestionParams{}, ErrInvalidKnockout},
+		{"one option", url.Values{"prompt": {"Level?"}, "kind": {KindSingleChoice}, "options": {"A\na"}}, sqlc.CreateScreeningQuestionParams{}, ErrMissingOptions},
+		{"number range", url.Values{"prompt": {"Years of Go?"}, "kind": {KindNumber}, "knockout_min": {" 2 "}, "knockout_max": {"40"}}, sqlc.CreateScreeningQuestionParams{
+			Prompt: "Years of Go?", Kind: KindNumber, Options: []string{}, KnockoutAnswers: []string{}, KnockoutMin: bound(2), KnockoutMax: bound(40),
+		}, nil},
+		{"number minimum only", url.Values{"prompt": {"Years?"}, "kind": {KindNumber}, "knockout_min": {"3"}}, sqlc.CreateScreeningQuestionParams{
+			Prompt: "Years?", Kind: KindNumber, Options: []string{}, KnockoutAnswers: []string{}, KnockoutMin: bound(3),
+		}, nil},
+		{"number range reversed", url.Values{"prompt": {"Years?"}, "kind": {KindNumber}, "knockout_min": {"5"}, "knockout_max": {"2"}}, sqlc.CreateScreeningQuestionParams{}, ErrInvalidKnockout},
+		{"number bound not whole", url.Values{"prompt": {"Years?"}, "kind": {KindNumber}, "knockout_min": {"2.5"}}, sqlc.CreateScreeningQuestionParams{}, ErrInvalidKnockout},
+		{"number with knockout answers", url.Values{"prompt": {"Years?"}, "kind": {KindNumber}, "knockout_answers": {"0"}}, sqlc.CreateScreeningQuestionParams{}, ErrInvalidKnockout},
+		{"missing prompt", url.Values{"prompt": {"   "}, "kind": {KindText}}, sqlc.CreateScreeningQuestionParams{}, ErrMissingPrompt},
+		{"unknown kind", url.Values{"prompt": {"Why?"}, "kind": {"essay"}}, sqlc.CreateScreeningQuestionParams{}, ErrInvalidKind},
+	}
+	jobPostingID := uuid.New()
+	for _, tt := range tests {
+		got, err := ParseQuestion(jobPostingID, tt.form)
+		if err != tt.wantErr {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		tt.want.JobPostingID = jobPostingID
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ParseQuestion = %+v\nwant %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	permit := sqlc.ScreeningQuestion{ID: uuid.New(), Prompt: "Work permit?", Kind: KindYesNo, Options: []string{"Yes", "No"}, Required: true, KnockoutAnswers: []string{"No"}}
+	years := sqlc.ScreeningQuestion{ID: uuid.New(), Prompt: "Years of Go?", Kind: KindNumber, KnockoutMin: sql.NullInt32{Int32: 2, Valid: true}, KnockoutMax: sql.NullInt32{Int32: 40, Valid: true}}
+	stack := sqlc.ScreeningQuestion{ID: uuid.New(), Prompt: "Stack?", Kind: KindMultiChoice, Options: []string{"Go", "Java", "PHP"}, KnockoutAnswers: []string{"PHP"}}
+	why := sqlc.ScreeningQuestion{ID: uuid.New(), Prompt: "Why us?", Kind: KindText}
+	questions := []sqlc.ScreeningQuestion{permit, years, stack, why}
+
+	field := func(q sqlc.ScreeningQuestion) string { return FieldPrefix + q.ID.String() }
+	type result struct {
+		values     []string
+		knockedOut bool
+	}
+	tests := []struct {
+		name    string
+		form    url.Values
+		want    []result
+		wantErr error
+	}{
+		{"passes", url.Values{
+			field(permit): {"yes"},
+			field(years):  {" 5 "},
+			field(stack):  {"go", "Java", "GO"},
+			field(why):    {"  Remote work  "},
+		}, []result{{[]string{"Yes"}, false}, {[]string{"5"}, false}, {[]string{"Go", "Java"}, false}, {[]string{"Remote work"}, false}}, nil},
+		{"optional questions skipped", url.Values{
+			field(permit): {"Yes"},
+			field(years):  {"  "},
+		}, []result{{[]string{"Yes"}, false}, {[]string{}, false}, {[]string{}, false}, {[]string{}, false}}, nil},
+		{"knocked out by answer", url.Values{field(permit): {"No"}}, []result{{[]string{"No"}, true}, {[]string{}, false}, {[]string{}, false}, {[]string{}, false}}, nil},
+		{"knocked out by one of several", url.Values{
+			field(permit): {"Yes"},
+			field(stack):  {"Go", "php"},
+		}, []result{{[]string{"Yes"}, false}, {[]string{}, false}, {[]string{"Go", "PHP"}, true}, {[]string{}, false}}, nil},
+		{"below minimum", url.Values{field(permit): {"Yes"}, field(years): {"1"}}, []result{{[]string{"Yes"}, false}, {[]string{"1"}, true}, {[]string{}, false}, {[]string{}, false}}, nil},
+		{"above maximum", url.Values{field(permit): {"Yes"}, field(years): {"41"}}, []result{{[]string{"Yes"}, false}, {[]string{"41"}, true}, {[]string{}, false}, {[]string{}, false}}, nil},
+		{"at the bounds", url.Values{field(permit): {"Yes"}, field(years): {"2"}}, []result{{[]string{"Yes"}, false}, {[]string{"2"}, false}, {[]string{}, false}, {[]string{}, false}}, nil},
+		{"only the first of a single answer", url.Values{field(permit): {"Yes", "No"}}, []result{{[]string{"Yes"}, false}, {[]string{}, false}, {[]string{}, false}, {[]string{}, false}}, nil},
+		{"required missing", url.Values{field(years): {"5"}}, nil, ErrAnswerRequired},
+		{"not an option", url.Values{field(permit): {"Maybe"}}, nil, ErrInvalidAnswer},
+		{"not a number", url.Values{field(permit): {"Yes"}, field(years): {"five"}}, nil, ErrInvalidAnswer},
+	}
+	for _, tt := range tests {
+		answers, err := Evaluate(questions, tt.form)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		var got []result
+		for i, a := range answers {
+			if a.Question.ID != questions[i].ID {
+				t.Errorf("%s: answer %d is to %q, want %q", tt.name, i, a.Question.Prompt, questions[i].Prompt)
+			}
+			got = append(got, result{a.Values, a.KnockedOut})
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Evaluate = %+v\nwant %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheckStage(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     error
+	}{
+		{"applied", "screening", nil},
+		{"applied", "offer", nil},
+		{"interview", "screening", nil},
+		{"offer", "hired", nil},
+		{"interview", "rejected", nil},
+		{"rejected", "interview", nil},
+		{"applied", "applied", ErrStageUnchanged},
+		{"applied", "withdrawn", ErrInvalidStage},
+		{"applied", "", ErrInvalidStage},
+		{"hired", "rejected", ErrApplicationHired},
+		{"hired", "hired", ErrStageUnchanged},
+	}
+	for _, tt := range tests {
+		if got := CheckStage(tt.from, tt.to); got != tt.want {
+			t.Errorf("CheckStage(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package skills
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"gin-app/db"
+	sqlc "gin-app/db/sqlc"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Proficiencies are the levels an applicant can claim in a skill, lowest
+// first.
+var Proficiencies = []string{"beginner", "intermediate", "advanced", "expert"}
+
+// DefaultProficiency is used when an applicant lists a skill without a level.
+const DefaultProficiency = "intermediate"
+
+var (
+	ErrInvalidProficiency = errors.New("proficiency must be beginner, intermediate, advanced or expert")
+	ErrInvalidYears       = errors.New("years with a skill must be a whole number from 0 to 70")
+)
+
+// Service keeps skill names canonical. Every name written to a posting,
+// profile or saved search goes through it, so "Go", "golang " and "GoLang"
+// all become the one Go skill.
+type Service struct {
+	DB      db.TxBeginner
+	Queries *sqlc.Queries
+}
+
+func NewService(conn db.TxBeginner, queries *sqlc.Queries) *Service {
+	return &Service{DB: conn, Queries: queries}
+}
+
+// Key is how skill names and aliases are compared: trimmed, lower-cased and
+// with inner whitespace collapsed. It matches skill_key in the database.
+func Key(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Split reads a comma-separated skills field, dropping blank entries.
+func Split(s string) []string {
+	names := []string{}
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.Join(strings.Fields(name), " "); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Resolve returns the skill name or alias refers to, adding it to the
+// taxonomy as a new skill if it is not known yet. Only curated skills are
+// suggested by autocomplete: names recruiters post with are curated, while
+// names applicants type are not until a recruiter uses them too.
+func Resolve(ctx context.Context, q *sqlc.Queries, name string, curated bool) (sqlc.Skill, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	skill, err := q.GetSkillByKey(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && curated && !skill.Curated) {
+		return q.CreateSkill(ctx, sqlc.CreateSkillParams{Name: name, Curated: curated})
+	}
+	return skill, err
+}
+
+// Canonicalize resolves each name, dropping blanks and names that resolve to
+// a skill already listed.
+func Canonicalize(ctx context.Context, q *sqlc.Queries, names []string, curated bool) ([]sqlc.Skill, error) {
+	skills := []sqlc.Skill{}
+	for _, name := range names {
+		if Key(name) == "" {
+			continue
+		}
+		skill, err := Resolve(ctx, q, name, curated)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.ContainsFunc(skills, func(s sqlc.Skill) bool { return s.ID == skill.ID }) {
+			skills = append(skills, skill)
+		}
+	}
+	return skills, nil
+}
+
+// Names lists the skills' canonical names.
+func Names(skills []sqlc.Skill) []string {
+	names := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		names = append(names, skill.Name)
+	}
+	return names
+}
+
+// Canonical replaces each known name or alias with its skill's canonical
+// name, dropping blanks and duplicates. Unknown names are kept as typed and
+// not added to the taxonomy, which is what search terms want.
+func (s *Service) Canonical(ctx context.Context, names []string) ([]string, error) {
+	out := []string{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = strings.Join(strings.Fields(name), " ")
+		if name == "" {
+			continue
+		}
+		skill, err := s.Queries.GetSkillByKey(ctx, name)
+		switch {
+		case err == nil:
+			name = skill.Name
+		case !errors.Is(err, sql.ErrNoRows):
+			return nil, err
+		}
+		if !seen[Key(name)] {
+			seen[Key(name)] = true
+			out = append(out, name)
+		}
+	}
+	return out, nil
+}
+
+// Search suggests up to limit curated skills whose name or an alias starts
+// with prefix, most used by postings first.
+func (s *Service) Search(ctx context.Context, prefix string, limit int32) ([]sqlc.SearchSkillsRow, error) {
+	key := Key(prefix)
+	if key == "" {
+		return []sqlc.SearchSkillsRow{}, nil
+	}
+	return s.Queries.SearchSkills(ctx, sqlc.SearchSkillsParams{
+		Prefix:   likeEscaper.Replace(key),
+		RowLimit: limit,
+	})
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ApplicantSkill is one skill as an applicant lists it on their profile.
+type ApplicantSkill struct {
+	Name        string
+	Proficiency string
+	Years       sql.NullInt32
+}
+
+// ParseApplicantSkills reads the profile's skills table: one skill,
+// proficiency and years field per row, in order. Rows without a skill name
+// are skipped, so the form can offer blank rows for new skills.
+func ParseApplicantSkills(form url.Values) ([]ApplicantSkill, error) {
+	names, levels, years := form["skill"], form["proficiency"], form["years"]
+	var out []ApplicantSkill
+	for i, name := range names {
+		if Key(name) == "" {
+			continue
+		}
+		skill := ApplicantSkill{Name: name}
+		if i < len(levels) {
+			skill.Proficiency = levels[i]
+		}
+		if i < len(years) && strings.TrimSpace(years[i]) != "" {
+			n, err := strconv.ParseInt(strings.TrimSpace(years[i]), 10, 32)
+			if err != nil {
+				return nil, ErrInvalidYears
+			}
+			skill.Years = sql.NullInt32{Int32: int32(n), Valid: true}
+		}
+		out = append(out, skill)
+	}
+	return out, nil
+}
+
+// SetApplicantSkills replaces the applicant's skills with the given ones,
+// resolved to canonical skills; if two resolve to the same skill the last
+// wins. The applicant's skill set, which matching and analytics read, is
+// rewritten with the canonical names, which are returned.
+func (s *Service) SetApplicantSkills(ctx context.Context, applicantID uuid.UUID, list []ApplicantSkill) ([]string, error) {
+	for i := range list {
+		if list[i].Proficiency == "" {
+			list[i].Proficiency = DefaultProficiency
+		}
+		if !slices.Contains(Proficiencies, list[i].Proficiency) {
+			return nil, ErrInvalidProficiency
+		}
+		if y := list[i].Years; y.Valid && (y.Int32 < 0 || y.Int32 > 70) {
+			return nil, ErrInvalidYears
+		}
+	}
+	names := []string{}
+	err := db.InTx(ctx, s.DB, func(q *sqlc.Queries) error {
+		if err := q.DeleteApplicantSkills(ctx, applicantID); err != nil {
+			return err
+		}
+		for _, entry := range list {
+			skill, err := Resolve(ctx, q, entry.Name, false)
+			if err != nil {
+				return err
+			}
+			err = q.AddApplicantSkill(ctx, sqlc.AddApplicantSkillParams{
+				ApplicantID: applicantID,
+				SkillID:     skill.ID,
+				Proficiency: entry.Proficiency,
+				Years:       entry.Years,
+			})
+			if err != nil {
+				return err
+			}
+			if !slices.Contains(names, skill.Name) {
+				names = append(names, skill.Name)
+			}
+		}
+		return q.UpdateApplicantSkills(ctx, sqlc.UpdateApplicantSkillsParams{ApplicantID: applicantID, Skills: names})
+	})
+	return names, err
+}
+
+// CreateJobPost creates a posting with its required and preferred skills
+// resolved to canonical skills. A skill listed as both is required. The
+// params are returned as stored.
+func (s *Service) CreateJobPost(ctx context.Context, params sqlc.CreateJobPostParams) (sqlc.CreateJobPostParams, error) {
+	err := db.InTx(ctx, s.DB, func(q *sqlc.Queries) error {
+		required, err := Canonicalize(ctx, q, params.Skills, true)
+		if err != nil {
+			return err
+		}
+		preferred, err := Canonicalize(ctx, q, params.PreferredSkills, true)
+		if err != nil {
+			return err
+		}
+		preferred = slices.DeleteFunc(preferred, func(p sqlc.Skill) bool {
+			return slices.ContainsFunc(required, func(r sqlc.Skill) bool { return r.ID == p.ID })
+		})
+		params.Skills, params.PreferredSkills = Names(required), Names(preferred)
+		if err := q.CreateJobPost(ctx, params); err != nil {
+			return err
+		}
+		for _, group := range []struct {
+			skills   []sqlc.Skill
+			required bool
+		}{{required, true}, {preferred, false}} {
+			for _, skill := range group.skills {
+				err := q.AddJobPostingSkill(ctx, sqlc.AddJobPostingSkillParams{
+					JobPostingID: params.ID,
+					SkillID:      skill.ID,
+					Required:     group.required,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	return params, err
+}
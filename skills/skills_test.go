@@ -0,0 +1,134 @@
+package skills
+
+import (
+	"context"
+	"database/sql"
+	"gin-app/db/dbtest"
+	sqlc "gin-app/db/sqlc"
+	"net/url"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Go", "go"},
+		{"  GoLang ", "golang"},
+		{"Machine   Learning", "machine learning"},
+		{"machine\tlearning\n", "machine learning"},
+		{"C++", "c++"},
+		{"   ", ""},
+	}
+	for _, tt := range tests {
+		if got := Key(tt.name); got != tt.want {
+			t.Errorf("Key(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		field string
+		want  []string
+	}{
+		{"Go, PostgreSQL,Docker", []string{"Go", "PostgreSQL", "Docker"}},
+		{" Machine  Learning ,  ", []string{"Machine Learning"}},
+		{"Go,,go, ", []string{"Go", "go"}},
+		{"", []string{}},
+		{" , ,", []string{}},
+	}
+	for _, tt := range tests {
+		if got := Split(tt.field); !slices.Equal(got, tt.want) {
+			t.Errorf("Split(%q) = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestParseApplicantSkills(t *testing.T) {
+	years := func(n int32) sql.NullInt32 { return sql.NullInt32{Int32: n, Valid: true} }
+	tests := []struct {
+		name    string
+		form    url.Values
+		want    []ApplicantSkill
+		wantErr error
+	}{
+		{"rows in order", url.Values{
+			"skill":       {"Go", "golang ", "Docker"},
+			"proficiency": {"expert", "", "beginner"},
+			"years":       {"5", "", " 1 "},
+		}, []ApplicantSkill{
+			{Name: "Go", Proficiency: "expert", Years: years(5)},
+			{Name: "golang ", Proficiency: ""},
+			{Name: "Docker", Proficiency: "beginner", Years: years(1)},
+		}, nil},
+		{"blank rows skipped", url.Values{
+			"skill":       {"", "Go", "  "},
+			"proficiency": {"expert", "advanced", "beginner"},
+			"years":       {"9", "", "3"},
+		}, []ApplicantSkill{{Name: "Go", Proficiency: "advanced"}}, nil},
+		{"short columns", url.Values{"skill": {"Go", "Rust"}, "proficiency": {"expert"}}, []ApplicantSkill{
+			{Name: "Go", Proficiency: "expert"},
+			{Name: "Rust"},
+		}, nil},
+		{"years not a number", url.Values{"skill": {"Go"}, "years": {"five"}}, nil, ErrInvalidYears},
+		{"no skills", url.Values{}, nil, nil},
+	}
+	for _, tt := range tests {
+		got, err := ParseApplicantSkills(tt.form)
+		if err != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ParseApplicantSkills = %+v, %v; want %+v, %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestLikeEscaper(t *testing.T) {
+	if got, want := likeEscaper.Replace(`50%_off\`), `50\%\_off\\`; got != want {
+		t.Errorf("likeEscaper.Replace = %q, want %q", got, want)
+	}
+}
+
+func TestResolveCuration(t *testing.T) {
+	conn := dbtest.Open(t)
+	ctx := context.Background()
+	q := sqlc.New(conn)
+	service := NewService(conn, q)
+	name := "Zz " + uuid.NewString()[:8]
+	suggested := func() bool {
+		rows, err := service.Search(ctx, name, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return slices.ContainsFunc(rows, func(r sqlc.SearchSkillsRow) bool { return r.Name == name })
+	}
+
+	typed, err := Resolve(ctx, q, name, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Exec("DELETE FROM skills WHERE id = $1", typed.ID) })
+	if typed.Curated || suggested() {
+		t.Errorf("skill an applicant typed is curated %v, suggested %v; want neither", typed.Curated, suggested())
+	}
+
+	posted, err := Resolve(ctx, q, " "+name+" ", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if posted.ID != typed.ID || !posted.Curated || !suggested() {
+		t.Errorf("skill a recruiter posted with is %v curated %v, suggested %v; want the same skill, curated and suggested", posted.ID == typed.ID, posted.Curated, suggested())
+	}
+
+	again, err := Resolve(ctx, q, name, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Curated {
+		t.Error("an applicant listing a curated skill made it uncurated")
+	}
+}
@@ -0,0 +1,30 @@
+// Draws the charts on the admin analytics dashboard. Each canvas carries its
+// Chart.js type and data as JSON in a data-chart attribute.
+(function() {
+    var colors = ["rgb(13, 71, 161)", "rgb(101, 181, 194)", "rgb(255, 152, 0)", "rgb(76, 175, 80)", "rgb(200, 35, 51)"];
+    var canvases = document.querySelectorAll("canvas.analytics-chart");
+    for (var i = 0; i < canvases.length; i++) {
+        var chart = JSON.parse(canvases[i].getAttribute("data-chart"));
+        var line = chart.type === "line";
+        var datasets = (chart.datasets || []).map(function(dataset, j) {
+            return {
+                label: dataset.label,
+                data: dataset.data || [],
+                borderColor: colors[j % colors.length],
+                backgroundColor: line ? "rgba(0, 0, 0, 0)" : colors[j % colors.length],
+                borderWidth: line ? 2 : 1,
+                pointRadius: line ? 0 : undefined
+            };
+        });
+        var axis = [{ ticks: { beginAtZero: true, precision: 0 } }];
+        new Chart(canvases[i], {
+            type: chart.type,
+            data: { labels: chart.labels || [], datasets: datasets },
+            options: {
+                responsive: true,
+                legend: { display: datasets.length > 1 },
+                scales: chart.type === "horizontalBar" ? { xAxes: axis } : { yAxes: axis }
+            }
+        });
+    }
+})();
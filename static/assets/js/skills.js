@@ -0,0 +1,55 @@
+// Suggests canonical skill names on inputs marked data-skill-autocomplete,
+// using a datalist filled from /skills. Inputs that also carry
+// data-skill-multiple hold a comma-separated list, and only the skill being
+// typed after the last comma is completed.
+(function() {
+    var inputs = document.querySelectorAll("input[data-skill-autocomplete]");
+    for (var i = 0; i < inputs.length; i++) {
+        attach(inputs[i], i);
+    }
+
+    function attach(input, n) {
+        var multiple = input.hasAttribute("data-skill-multiple");
+        var list = document.createElement("datalist");
+        list.id = "skill-suggestions-" + n;
+        document.body.appendChild(list);
+        input.setAttribute("list", list.id);
+        input.setAttribute("autocomplete", "off");
+
+        var timer, request;
+        input.addEventListener("input", function() {
+            clearTimeout(timer);
+            timer = setTimeout(suggest, 200);
+        });
+
+        function suggest() {
+            var value = input.value;
+            var cut = multiple ? value.lastIndexOf(",") + 1 : 0;
+            var head = value.slice(0, cut);
+            var term = value.slice(cut).replace(/^\s+/, "");
+            if (request) {
+                request.abort();
+            }
+            if (!term) {
+                list.innerHTML = "";
+                return;
+            }
+            request = new XMLHttpRequest();
+            request.open("GET", "/skills?q=" + encodeURIComponent(term));
+            request.onload = function() {
+                if (request.status !== 200) {
+                    return;
+                }
+                var skills = JSON.parse(request.responseText).skills || [];
+                list.innerHTML = "";
+                for (var j = 0; j < skills.length; j++) {
+                    var option = document.createElement("option");
+                    option.value = (head ? head.replace(/\s*$/, " ") : "") + skills[j].name;
+                    option.label = skills[j].category;
+                    list.appendChild(option);
+                }
+            };
+            request.send();
+        }
+    }
+})();
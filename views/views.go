@@ -0,0 +1,46 @@
+package views
+
+import (
+	"gin-app/authz"
+	"gin-app/middlewares"
+	"gin-app/tracing"
+	"html/template"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// FuncMap holds the helpers available to every template.
+var FuncMap = template.FuncMap{
+	"csrfField": CSRFField,
+	"can":       Can,
+}
+
+// CSRFField renders the hidden input CSRFMiddleware expects in every POST
+// form: {{ csrfField $.csrfToken }}.
+func CSRFField(token string) template.HTML {
+	return template.HTML(`<input type="hidden" name="` + middlewares.CSRFFormField + `" value="` + template.HTMLEscapeString(token) + `">`)
+}
+
+// Can reports whether the signed-in user holds every one of perms, so
+// templates can hide what the user cannot use:
+// {{ if can $.permissions "jobs:write" }}.
+func Can(perms authz.Set, want ...string) bool {
+	return perms.Has(want...)
+}
+
+// HTML renders a template with the request's CSRF token and the user's
+// permissions added to gin.H data, inside its own span so slow templates show
+// up separately from the queries that fed them.
+func HTML(c *gin.Context, code int, name string, obj any) {
+	if data, ok := obj.(gin.H); ok {
+		data["csrfToken"] = middlewares.CSRFToken(c)
+		data["permissions"] = middlewares.Permissions(c)
+	}
+	_, span := tracing.Tracer.Start(c.Request.Context(), "render "+name, trace.WithAttributes(
+		attribute.String("template.name", name),
+	))
+	defer span.End()
+	c.HTML(code, name, obj)
+}
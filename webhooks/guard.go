@@ -0,0 +1,99 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// Subscribers choose the URLs deliveries go to, so without a guard a
+// subscription could make the worker POST into the portal's own network:
+// the database, an admin port on localhost or the cloud metadata endpoint.
+// URLs are checked when a subscription is created, and every connection the
+// worker makes is checked again once the name is resolved, since DNS can
+// start pointing anywhere after the subscription was accepted.
+
+var ErrForbiddenAddress = errors.New("webhook URL must resolve to a public internet address")
+
+// reserved are ranges PublicAddr rejects beyond those netip classifies.
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // reserved, and broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which reaches any IPv4 address
+}
+
+// PublicAddr reports whether deliveries may be sent to addr: it is not
+// loopback, private, link-local (which covers the 169.254.169.254 metadata
+// endpoint), multicast, unspecified or otherwise reserved. IPv4 addresses
+// mapped into IPv6 are judged as IPv4.
+func PublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range reserved {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkHost resolves host and fails unless every address it has is public.
+func checkHost(ctx context.Context, host string) error {
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil || len(addrs) == 0 {
+		return errors.New("webhook URL host could not be resolved")
+	}
+	for _, addr := range addrs {
+		if !PublicAddr(addr) {
+			return ErrForbiddenAddress
+		}
+	}
+	return nil
+}
+
+// dialControl runs after resolution, just before each connection is made.
+func dialControl(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !PublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("dialing %s: %w", address, ErrForbiddenAddress)
+	}
+	return nil
+}
+
+// NewClient returns the HTTP client deliveries are sent with. It refuses to
+// connect to addresses that are not public, ignores proxy settings, whose
+// address is all the guard would see, and does not follow redirects, which
+// could send a checked URL anywhere; a redirect counts as a failed delivery.
+func NewClient(timeout time.Duration) *http.Client {
+	return newClient(timeout, dialControl)
+}
+
+// newClient is NewClient with the dial check replaceable, so tests can
+// deliver to a local server.
+func newClient(timeout time.Duration, control func(network, address string, c syscall.RawConn) error) *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, KeepAlive: 30 * time.Second, Control: control}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
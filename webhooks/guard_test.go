@@ -0,0 +1,93 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"64:ff9b::a9fe:a9fe", false},
+	}
+	for _, tt := range tests {
+		if got := PublicAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("PublicAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestCreateSubscriptionRejectsInternalURLs(t *testing.T) {
+	s := &Service{}
+	for _, rawURL := range []string{
+		"http://127.0.0.1:5432/",
+		"http://localhost/hook",
+		"http://169.254.169.254/latest/meta-data/",
+		"https://[::1]/hook",
+		"http://10.0.0.7/hook",
+		"http://0x7f000001/hook",
+	} {
+		_, err := s.CreateSubscription(context.Background(), uuid.New(), rawURL, []string{EventJobPosted})
+		if err == nil {
+			t.Errorf("CreateSubscription(%q) succeeded", rawURL)
+		}
+	}
+}
+
+func TestClientRefusesInternalAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("guarded client reached a loopback server")
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(time.Second).Post(srv.URL, "application/json", nil)
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("got error %v, want %v", err, ErrForbiddenAddress)
+	}
+}
+
+func TestClientDoesNotFollowRedirects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/hook" {
+			http.Redirect(w, r, "/internal", http.StatusTemporaryRedirect)
+			return
+		}
+		t.Errorf("redirect to %s was followed", r.URL.Path)
+	}))
+	defer srv.Close()
+
+	resp, err := newClient(time.Second, nil).Post(srv.URL+"/hook", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTemporaryRedirect {
+		t.Fatalf("got status %d, want %d", resp.StatusCode, http.StatusTemporaryRedirect)
+	}
+}
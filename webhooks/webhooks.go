@@ -0,0 +1,163 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	db "gin-app/db/sqlc"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types a company can subscribe to. Only events something publishes
+// belong here.
+const (
+	EventJobPosted      = "job.posted"
+	EventCandidateHired = "candidate.hired"
+)
+
+var EventTypes = []string{EventJobPosted, EventCandidateHired}
+
+// Headers sent with every delivery. The signature is an HMAC-SHA256 over
+// "<timestamp>.<body>" keyed with the subscription secret, so receivers can
+// reject both forged and replayed requests.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+type Service struct {
+	Queries *db.Queries
+}
+
+func NewService(queries *db.Queries) *Service {
+	return &Service{Queries: queries}
+}
+
+// Envelope is the JSON body of every delivery.
+type Envelope struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// JobPostedData is the payload of a job.posted event.
+func JobPostedData(p db.CreateJobPostParams) map[string]any {
+	return map[string]any{
+		"id":               p.ID,
+		"company_id":       p.CompanyID.UUID,
+		"company_name":     p.CompanyName,
+		"position":         p.Position,
+		"skills":           p.Skills,
+		"preferred_skills": p.PreferredSkills,
+		"description":      p.Description.String,
+		"salary":           p.Salary.String,
+	}
+}
+
+// CandidateHiredData is the payload of a candidate.hired event.
+func CandidateHiredData(a db.Application, p db.JobPosting, applicant db.User) map[string]any {
+	return map[string]any{
+		"application_id": a.ID,
+		"job_posting_id": p.ID,
+		"company_id":     p.CompanyID.UUID,
+		"position":       p.Position,
+		"applicant_id":   applicant.ID,
+		"name":           applicant.Name,
+		"email":          applicant.Email,
+		"source":         a.Source,
+		"applied_at":     a.CreatedAt,
+		"hired_at":       a.UpdatedAt,
+	}
+}
+
+// Sign returns the value of the signature header for a delivery body.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature matches the body. It is the receiving
+// side of Sign and is exported for receivers written in Go.
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// CreateSubscription validates and stores a subscription with a freshly
+// generated signing secret. The URL's host must resolve to public addresses
+// only.
+func (s *Service) CreateSubscription(ctx context.Context, companyID uuid.UUID, rawURL string, eventTypes []string) (db.WebhookSubscription, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return db.WebhookSubscription{}, errors.New("webhook URL must be an absolute http(s) URL")
+	}
+	if err := checkHost(ctx, u.Hostname()); err != nil {
+		return db.WebhookSubscription{}, err
+	}
+	if len(eventTypes) == 0 {
+		return db.WebhookSubscription{}, errors.New("at least one event type is required")
+	}
+	for _, t := range eventTypes {
+		if !slices.Contains(EventTypes, t) {
+			return db.WebhookSubscription{}, errors.New("unknown event type: " + t)
+		}
+	}
+
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return db.WebhookSubscription{}, err
+	}
+	return s.Queries.CreateWebhookSubscription(ctx, db.CreateWebhookSubscriptionParams{
+		CompanyID:  companyID,
+		Url:        u.String(),
+		Secret:     "whsec_" + hex.EncodeToString(b),
+		EventTypes: eventTypes,
+	})
+}
+
+// Publish queues a delivery of the event to every active subscription of the
+// company that listens for it. Delivery itself happens in the Worker.
+func (s *Service) Publish(ctx context.Context, companyID uuid.UUID, eventType string, data any) error {
+	subs, err := s.Queries.ListActiveWebhookSubscriptionsForEvent(ctx, db.ListActiveWebhookSubscriptionsForEventParams{
+		CompanyID: companyID,
+		EventType: eventType,
+	})
+	if err != nil || len(subs) == 0 {
+		return err
+	}
+
+	payload, err := json.Marshal(Envelope{
+		ID:        uuid.New(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subs {
+		_, err := s.Queries.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+			SubscriptionID: sub.ID,
+			EventType:      eventType,
+			Payload:        payload,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package webhooks
+
+import "testing"
+
+func TestSign(t *testing.T) {
+	got := Sign("whsec_test", "1700000000", []byte(`{"id":1}`))
+	want := "sha256=2f441ba4b3b2d50d28a9ab9d9fd8880376ecd1eb5d0435401553f5d8d0a5dcf8"
+	if got != want {
+		t.Errorf("Sign = %s, want %s", got, want)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	const (
+		secret    = "whsec_test"
+		timestamp = "1700000000"
+	)
+	body := []byte(`{"id":1}`)
+	signature := Sign(secret, timestamp, body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      string
+		signature string
+		want      bool
+	}{
+		{"valid", secret, timestamp, `{"id":1}`, signature, true},
+		{"wrong secret", "whsec_other", timestamp, `{"id":1}`, signature, false},
+		{"replayed with new timestamp", secret, "1700000300", `{"id":1}`, signature, false},
+		{"tampered body", secret, timestamp, `{"id":2}`, signature, false},
+		{"missing prefix", secret, timestamp, `{"id":1}`, signature[len("sha256="):], false},
+		{"upper-case hex", secret, timestamp, `{"id":1}`, "sha256=2F441BA4B3B2D50D28A9AB9D9FD8880376ECD1EB5D0435401553F5D8D0A5DCF8", false},
+		{"empty signature", secret, timestamp, `{"id":1}`, "", false},
+	}
+	for _, tt := range tests {
+		if got := Verify(tt.secret, tt.timestamp, []byte(tt.body), tt.signature); got != tt.want {
+			t.Errorf("%s: Verify = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
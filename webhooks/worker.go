@@ -0,0 +1,177 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	db "gin-app/db/sqlc"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxAttempts is how many times a delivery is tried before it is marked
+	// failed. Failed deliveries can still be redelivered by hand.
+	MaxAttempts = 8
+	// MaxConsecutiveFailures disables a subscription whose endpoint keeps
+	// failing, across all of its deliveries.
+	MaxConsecutiveFailures = 20
+
+	baseBackoff     = 30 * time.Second
+	maxBackoff      = 6 * time.Hour
+	maxResponseBody = 2048
+)
+
+// Store is the part of the queries the Worker runs; *db.Queries
+// implements it.
+type Store interface {
+	ClaimDueWebhookDeliveries(ctx context.Context, limit int32) ([]db.WebhookDelivery, error)
+	GetWebhookSubscriptionByID(ctx context.Context, id uuid.UUID) (db.WebhookSubscription, error)
+	MarkWebhookDeliverySucceeded(ctx context.Context, arg db.MarkWebhookDeliverySucceededParams) error
+	MarkWebhookDeliveryFailed(ctx context.Context, arg db.MarkWebhookDeliveryFailedParams) error
+	RecordWebhookSuccess(ctx context.Context, id uuid.UUID) error
+	RecordWebhookFailure(ctx context.Context, id uuid.UUID) (int32, error)
+	DisableWebhookSubscription(ctx context.Context, id uuid.UUID) error
+}
+
+// Worker polls for due deliveries and POSTs them to subscriber endpoints.
+// Deliveries are claimed with a lease, so several workers can run at once.
+type Worker struct {
+	Store     Store
+	Client    *http.Client
+	Interval  time.Duration
+	BatchSize int32
+}
+
+func NewWorker(queries *db.Queries) *Worker {
+	return &Worker{
+		Store:     queries,
+		Client:    NewClient(10 * time.Second),
+		Interval:  5 * time.Second,
+		BatchSize: 20,
+	}
+}
+
+// Run delivers webhooks until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		if err := w.ProcessDue(ctx); err != nil {
+			slog.Error("processing webhook deliveries", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue claims one batch of due deliveries and attempts each of them.
+func (w *Worker) ProcessDue(ctx context.Context) error {
+	deliveries, err := w.Store.ClaimDueWebhookDeliveries(ctx, w.BatchSize)
+	if err != nil {
+		return err
+	}
+	for _, d := range deliveries {
+		if err := w.deliver(ctx, d); err != nil {
+			slog.Error("recording webhook delivery", "delivery_id", d.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+func (w *Worker) deliver(ctx context.Context, d db.WebhookDelivery) error {
+	sub, err := w.Store.GetWebhookSubscriptionByID(ctx, d.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	code, body, sendErr := w.send(ctx, sub, d)
+	responseCode := sql.NullInt32{Int32: int32(code), Valid: code != 0}
+	responseBody := sql.NullString{String: body, Valid: body != ""}
+
+	if sendErr == nil {
+		if err := w.Store.MarkWebhookDeliverySucceeded(ctx, db.MarkWebhookDeliverySucceededParams{
+			ID:           d.ID,
+			ResponseCode: responseCode,
+			ResponseBody: responseBody,
+		}); err != nil {
+			return err
+		}
+		return w.Store.RecordWebhookSuccess(ctx, sub.ID)
+	}
+
+	status := "pending"
+	if d.Attempts+1 >= MaxAttempts {
+		status = "failed"
+	}
+	if err := w.Store.MarkWebhookDeliveryFailed(ctx, db.MarkWebhookDeliveryFailedParams{
+		ID:            d.ID,
+		Status:        status,
+		ResponseCode:  responseCode,
+		ResponseBody:  responseBody,
+		LastError:     sql.NullString{String: sendErr.Error(), Valid: true},
+		NextAttemptAt: time.Now().Add(Backoff(int(d.Attempts) + 1)),
+	}); err != nil {
+		return err
+	}
+
+	failures, err := w.Store.RecordWebhookFailure(ctx, sub.ID)
+	if err != nil {
+		return err
+	}
+	if failures >= MaxConsecutiveFailures {
+		slog.Warn("disabling webhook subscription", "subscription_id", sub.ID, "consecutive_failures", failures)
+		return w.Store.DisableWebhookSubscription(ctx, sub.ID)
+	}
+	return nil
+}
+
+func (w *Worker) send(ctx context.Context, sub db.WebhookSubscription, d db.WebhookDelivery) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Url, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "recruitment-portal-webhooks/1.0")
+	req.Header.Set(HeaderEvent, d.EventType)
+	req.Header.Set(HeaderDelivery, d.ID.String())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, timestamp, d.Payload))
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	body := strings.ToValidUTF8(string(b), "")
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, body, fmt.Errorf("endpoint responded with %s", resp.Status)
+	}
+	return resp.StatusCode, body, nil
+}
+
+// Backoff returns the delay before retry number attempt: 30s, 1m, 2m, ...
+// capped at six hours.
+func Backoff(attempt int) time.Duration {
+	d := baseBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
@@ -0,0 +1,419 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	db "gin-app/db/sqlc"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memoryStore keeps subscriptions and deliveries in memory, updating them
+// the way the queries in webhooks.sql do.
+type memoryStore struct {
+	mu            sync.Mutex
+	subscriptions map[uuid.UUID]*db.WebhookSubscription
+	deliveries    map[uuid.UUID]*db.WebhookDelivery
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		subscriptions: map[uuid.UUID]*db.WebhookSubscription{},
+		deliveries:    map[uuid.UUID]*db.WebhookDelivery{},
+	}
+}
+
+func (m *memoryStore) addSubscription(url string) *db.WebhookSubscription {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub := &db.WebhookSubscription{
+		ID:         uuid.New(),
+		CompanyID:  uuid.New(),
+		Url:        url,
+		Secret:     "whsec_test",
+		EventTypes: EventTypes,
+		Active:     true,
+	}
+	m.subscriptions[sub.ID] = sub
+	return sub
+}
+
+func (m *memoryStore) addDelivery(sub *db.WebhookSubscription, payload string) *db.WebhookDelivery {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d := &db.WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: sub.ID,
+		EventType:      EventJobPosted,
+		Payload:        []byte(payload),
+		Status:         "pending",
+		NextAttemptAt:  time.Now(),
+		CreatedAt:      time.Now(),
+	}
+	m.deliveries[d.ID] = d
+	return d
+}
+
+// makeDue skips the wait before a delivery's next attempt.
+func (m *memoryStore) makeDue(id uuid.UUID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deliveries[id].NextAttemptAt = time.Now()
+}
+
+// redeliver is RedeliverWebhookDelivery.
+func (m *memoryStore) redeliver(id uuid.UUID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deliveries[id].Status = "pending"
+	m.deliveries[id].NextAttemptAt = time.Now()
+}
+
+func (m *memoryStore) delivery(id uuid.UUID) db.WebhookDelivery {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return *m.deliveries[id]
+}
+
+func (m *memoryStore) subscription(id uuid.UUID) db.WebhookSubscription {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return *m.subscriptions[id]
+}
+
+func (m *memoryStore) ClaimDueWebhookDeliveries(ctx context.Context, limit int32) ([]db.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []db.WebhookDelivery
+	for _, d := range m.deliveries {
+		if int32(len(out)) == limit {
+			break
+		}
+		if d.Status == "pending" && !d.NextAttemptAt.After(time.Now()) && m.subscriptions[d.SubscriptionID].Active {
+			d.NextAttemptAt = time.Now().Add(5 * time.Minute)
+			out = append(out, *d)
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryStore) GetWebhookSubscriptionByID(ctx context.Context, id uuid.UUID) (db.WebhookSubscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub, ok := m.subscriptions[id]
+	if !ok {
+		return db.WebhookSubscription{}, sql.ErrNoRows
+	}
+	return *sub, nil
+}
+
+func (m *memoryStore) MarkWebhookDeliverySucceeded(ctx context.Context, arg db.MarkWebhookDeliverySucceededParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d := m.deliveries[arg.ID]
+	d.Status = "succeeded"
+	d.Attempts++
+	d.ResponseCode, d.ResponseBody = arg.ResponseCode, arg.ResponseBody
+	d.LastError = sql.NullString{}
+	d.DeliveredAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return nil
+}
+
+func (m *memoryStore) MarkWebhookDeliveryFailed(ctx context.Context, arg db.MarkWebhookDeliveryFailedParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d := m.deliveries[arg.ID]
+	d.Status = arg.Status
+	d.Attempts++
+	d.ResponseCode, d.ResponseBody = arg.ResponseCode, arg.ResponseBody
+	d.LastError = arg.LastError
+	d.NextAttemptAt = arg.NextAttemptAt
+	return nil
+}
+
+func (m *memoryStore) RecordWebhookSuccess(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscriptions[id].ConsecutiveFailures = 0
+	return nil
+}
+
+func (m *memoryStore) RecordWebhookFailure(ctx context.Context, id uuid.UUID) (int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscriptions[id].ConsecutiveFailures++
+	return m.subscriptions[id].ConsecutiveFailures, nil
+}
+
+func (m *memoryStore) DisableWebhookSubscription(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscriptions[id].Active = false
+	m.subscriptions[id].DisabledAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return nil
+}
+
+// receiver is an endpoint that answers with status and records what it was
+// sent.
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newReceiver(t *testing.T, status int) *receiver {
+	r := &receiver{status: status}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		r.mu.Lock()
+		r.requests = append(r.requests, req)
+		r.bodies = append(r.bodies, body)
+		status := r.status
+		r.mu.Unlock()
+		w.WriteHeader(status)
+		io.WriteString(w, http.StatusText(status))
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) setStatus(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+func (r *receiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func newTestWorker(store Store) *Worker {
+	return &Worker{Store: store, Client: newClient(5*time.Second, nil), BatchSize: 20}
+}
+
+func process(t *testing.T, w *Worker) {
+	t.Helper()
+	if err := w.ProcessDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWorkerSignsDeliveries(t *testing.T) {
+	store := newMemoryStore()
+	recv := newReceiver(t, http.StatusOK)
+	sub := store.addSubscription(recv.URL)
+	payload := `{"type":"job.posted","data":{"position":"Go developer"}}`
+	d := store.addDelivery(sub, payload)
+
+	process(t, newTestWorker(store))
+
+	if recv.count() != 1 {
+		t.Fatalf("receiver got %d requests, want 1", recv.count())
+	}
+	req, body := recv.requests[0], recv.bodies[0]
+	if string(body) != payload {
+		t.Errorf("body = %s, want %s", body, payload)
+	}
+	if got := req.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if got := req.Header.Get(HeaderEvent); got != EventJobPosted {
+		t.Errorf("%s = %q, want %q", HeaderEvent, got, EventJobPosted)
+	}
+	if got := req.Header.Get(HeaderDelivery); got != d.ID.String() {
+		t.Errorf("%s = %q, want %q", HeaderDelivery, got, d.ID)
+	}
+	timestamp := req.Header.Get(HeaderTimestamp)
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || time.Since(time.Unix(sent, 0)).Abs() > time.Minute {
+		t.Errorf("%s = %q, want the current Unix time", HeaderTimestamp, timestamp)
+	}
+	if !Verify(sub.Secret, timestamp, body, req.Header.Get(HeaderSignature)) {
+		t.Errorf("%s = %q does not verify", HeaderSignature, req.Header.Get(HeaderSignature))
+	}
+
+	got := store.delivery(d.ID)
+	if got.Status != "succeeded" || got.Attempts != 1 || !got.DeliveredAt.Valid {
+		t.Errorf("delivery = %s after %d attempts, delivered %v; want succeeded after 1", got.Status, got.Attempts, got.DeliveredAt.Valid)
+	}
+	if got.ResponseCode.Int32 != http.StatusOK || got.ResponseBody.String != "OK" {
+		t.Errorf("response = %d %q, want 200 %q", got.ResponseCode.Int32, got.ResponseBody.String, "OK")
+	}
+}
+
+func TestWorkerRetriesWithBackoff(t *testing.T) {
+	store := newMemoryStore()
+	recv := newReceiver(t, http.StatusServiceUnavailable)
+	sub := store.addSubscription(recv.URL)
+	d := store.addDelivery(sub, `{}`)
+	w := newTestWorker(store)
+
+	for attempt := 1; attempt < MaxAttempts; attempt++ {
+		before := time.Now()
+		process(t, w)
+		got := store.delivery(d.ID)
+		if got.Status != "pending" || got.Attempts != int32(attempt) {
+			t.Fatalf("after attempt %d: delivery = %s after %d attempts, want pending", attempt, got.Status, got.Attempts)
+		}
+		if got.ResponseCode.Int32 != http.StatusServiceUnavailable || !got.LastError.Valid {
+			t.Errorf("after attempt %d: response %d, error %q", attempt, got.ResponseCode.Int32, got.LastError.String)
+		}
+		wait := got.NextAttemptAt.Sub(before)
+		if want := Backoff(attempt); wait < want || wait > want+time.Second {
+			t.Errorf("after attempt %d: next attempt in %v, want %v", attempt, wait, want)
+		}
+
+		// Not due yet: nothing is sent until the backoff has passed.
+		process(t, w)
+		if recv.count() != attempt {
+			t.Fatalf("receiver got %d requests after %d attempts", recv.count(), attempt)
+		}
+		store.makeDue(d.ID)
+	}
+
+	process(t, w)
+	if got := store.delivery(d.ID); got.Status != "failed" || got.Attempts != MaxAttempts {
+		t.Fatalf("delivery = %s after %d attempts, want failed after %d", got.Status, got.Attempts, MaxAttempts)
+	}
+	if got := store.subscription(sub.ID); got.ConsecutiveFailures != MaxAttempts || !got.Active {
+		t.Errorf("subscription has %d consecutive failures, active %v", got.ConsecutiveFailures, got.Active)
+	}
+	store.makeDue(d.ID)
+	process(t, w)
+	if recv.count() != MaxAttempts {
+		t.Errorf("failed delivery was retried: %d requests", recv.count())
+	}
+}
+
+func TestWorkerTreatsRedirectAsFailure(t *testing.T) {
+	store := newMemoryStore()
+	recv := newReceiver(t, http.StatusFound)
+	d := store.addDelivery(store.addSubscription(recv.URL), `{}`)
+
+	process(t, newTestWorker(store))
+
+	if got := store.delivery(d.ID); got.Status != "pending" || got.ResponseCode.Int32 != http.StatusFound {
+		t.Errorf("delivery = %s with response %d, want pending with 302", got.Status, got.ResponseCode.Int32)
+	}
+}
+
+func TestWorkerDisablesFailingSubscription(t *testing.T) {
+	store := newMemoryStore()
+	recv := newReceiver(t, http.StatusInternalServerError)
+	sub := store.addSubscription(recv.URL)
+	sub.ConsecutiveFailures = MaxConsecutiveFailures - 2
+	first := store.addDelivery(sub, `{"n":1}`)
+	w := newTestWorker(store)
+
+	process(t, w)
+	if got := store.subscription(sub.ID); !got.Active {
+		t.Fatalf("subscription disabled after %d consecutive failures", got.ConsecutiveFailures)
+	}
+
+	second := store.addDelivery(sub, `{"n":2}`)
+	process(t, w)
+	got := store.subscription(sub.ID)
+	if got.Active || !got.DisabledAt.Valid || got.ConsecutiveFailures != MaxConsecutiveFailures {
+		t.Fatalf("subscription active %v with %d consecutive failures, want disabled at %d", got.Active, got.ConsecutiveFailures, MaxConsecutiveFailures)
+	}
+
+	// Deliveries of a disabled subscription wait until it is enabled again.
+	store.makeDue(first.ID)
+	store.makeDue(second.ID)
+	process(t, w)
+	if recv.count() != 2 {
+		t.Errorf("receiver got %d requests, want 2", recv.count())
+	}
+}
+
+func TestWorkerSuccessResetsFailures(t *testing.T) {
+	store := newMemoryStore()
+	recv := newReceiver(t, http.StatusBadGateway)
+	sub := store.addSubscription(recv.URL)
+	d := store.addDelivery(sub, `{}`)
+	w := newTestWorker(store)
+
+	process(t, w)
+	store.makeDue(d.ID)
+	recv.setStatus(http.StatusNoContent)
+	process(t, w)
+
+	if got := store.delivery(d.ID); got.Status != "succeeded" || got.Attempts != 2 || got.LastError.Valid {
+		t.Errorf("delivery = %s after %d attempts, last error %q", got.Status, got.Attempts, got.LastError.String)
+	}
+	if got := store.subscription(sub.ID); got.ConsecutiveFailures != 0 {
+		t.Errorf("subscription has %d consecutive failures, want 0", got.ConsecutiveFailures)
+	}
+}
+
+func TestWorkerRedelivery(t *testing.T) {
+	store := newMemoryStore()
+	recv := newReceiver(t, http.StatusInternalServerError)
+	sub := store.addSubscription(recv.URL)
+	d := store.addDelivery(sub, `{"id":"redelivered"}`)
+	w := newTestWorker(store)
+
+	for range MaxAttempts {
+		store.makeDue(d.ID)
+		process(t, w)
+	}
+	if got := store.delivery(d.ID); got.Status != "failed" {
+		t.Fatalf("delivery = %s, want failed", got.Status)
+	}
+
+	recv.setStatus(http.StatusOK)
+	store.redeliver(d.ID)
+	process(t, w)
+
+	if recv.count() != MaxAttempts+1 {
+		t.Fatalf("receiver got %d requests, want %d", recv.count(), MaxAttempts+1)
+	}
+	first, last := recv.requests[0], recv.requests[MaxAttempts]
+	if first.Header.Get(HeaderDelivery) != last.Header.Get(HeaderDelivery) {
+		t.Errorf("redelivery has ID %s, want %s", last.Header.Get(HeaderDelivery), first.Header.Get(HeaderDelivery))
+	}
+	if string(recv.bodies[MaxAttempts]) != `{"id":"redelivered"}` {
+		t.Errorf("redelivered body = %s", recv.bodies[MaxAttempts])
+	}
+	if !Verify(sub.Secret, last.Header.Get(HeaderTimestamp), recv.bodies[MaxAttempts], last.Header.Get(HeaderSignature)) {
+		t.Error("redelivery signature does not verify")
+	}
+	if got := store.delivery(d.ID); got.Status != "succeeded" || got.Attempts != MaxAttempts+1 {
+		t.Errorf("delivery = %s after %d attempts, want succeeded", got.Status, got.Attempts)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{8, 64 * time.Minute},
+		{10, 256 * time.Minute},
+		{11, 6 * time.Hour},
+		{100, 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}